@@ -11,18 +11,22 @@ import (
 	"github.com/operator-framework/operator-registry/pkg/image/containerdregistry"
 
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
-	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
 func newCatalogAddCmd(cfg *action.Configuration) *cobra.Command {
-	a := internalaction.NewCatalogAdd(cfg)
+	a := action.NewCatalogAdd(cfg)
 	a.Logf = log.Printf
 
 	cmd := &cobra.Command{
 		Use:   "add <name> <index_image>",
 		Short: "Add an operator catalog",
-		Args:  cobra.ExactArgs(2),
+		Long: `Add an operator catalog by creating a catalog source for the index image.
+
+The catalog source is written with server-side apply, so adding a catalog that
+already exists updates it in place. Fields set by other field managers are not
+overwritten; such conflicts are reported as errors.`,
+		Args: cobra.ExactArgs(2),
 		PreRun: func(cmd *cobra.Command, args []string) {
 			regLogger := logrus.New()
 			regLogger.SetOutput(io.Discard)
@@ -38,7 +42,7 @@ func newCatalogAddCmd(cfg *action.Configuration) *cobra.Command {
 			if err != nil {
 				log.Fatalf("failed to add catalog: %v", err)
 			}
-			log.Printf("created catalogsource %q\n", cs.Name)
+			log.Printf("applied catalogsource %q\n", cs.Name)
 		},
 	}
 	bindCatalogAddFlags(cmd.Flags(), a)
@@ -46,7 +50,7 @@ func newCatalogAddCmd(cfg *action.Configuration) *cobra.Command {
 	return cmd
 }
 
-func bindCatalogAddFlags(fs *pflag.FlagSet, a *internalaction.CatalogAdd) {
+func bindCatalogAddFlags(fs *pflag.FlagSet, a *action.CatalogAdd) {
 	fs.StringVarP(&a.DisplayName, "display-name", "d", "", "display name of the index")
 	fs.StringVarP(&a.Publisher, "publisher", "p", "", "publisher of the index")
 	fs.DurationVar(&a.CleanupTimeout, "cleanup-timeout", time.Minute, "the amount of time to wait before cancelling cleanup")
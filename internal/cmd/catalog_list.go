@@ -9,22 +9,42 @@ import (
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
-	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
 func newCatalogListCmd(cfg *action.Configuration) *cobra.Command {
-	var allNamespaces bool
-	l := internalaction.NewCatalogList(cfg)
+	var (
+		allNamespaces bool
+		watch         watchFlags
+	)
+	l := action.NewCatalogList(cfg)
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List installed operator catalogs",
+		Long: `List installed operator catalogs.
+
+Use --watch to keep printing catalog sources as they are added, change or are
+deleted, like 'kubectl get --watch'. Each row starts with the event type. With
+--output json, each event is printed as a JSON watch event on its own line.`,
 		Run: func(cmd *cobra.Command, args []string) {
+			if err := watch.validate(); err != nil {
+				log.Fatal(err)
+			}
 			if allNamespaces {
 				cfg.Namespace = corev1.NamespaceAll
 			}
+			if watch.watch {
+				row := func(obj client.Object) string {
+					return catalogRow(*obj.(*v1alpha1.CatalogSource), allNamespaces)
+				}
+				runWatch(cmd, watch.output, catalogListHeader(allNamespaces), row, l.Watch)
+				return
+			}
 			catalogs, err := l.Run(cmd.Context())
 			if err != nil {
 				log.Fatal(err)
@@ -39,23 +59,31 @@ func newCatalogListCmd(cfg *action.Configuration) *cobra.Command {
 				return
 			}
 
-			nsCol := ""
-			if allNamespaces {
-				nsCol = "\tNAMESPACE"
-			}
 			tw := tabwriter.NewWriter(os.Stdout, 3, 4, 2, ' ', 0)
-			_, _ = fmt.Fprintf(tw, "NAME%s\tDISPLAY\tTYPE\tPUBLISHER\tAGE\n", nsCol)
+			_, _ = fmt.Fprint(tw, catalogListHeader(allNamespaces))
 			for _, cs := range catalogs {
-				ns := ""
-				if allNamespaces {
-					ns = "\t" + cs.Namespace
-				}
-				age := time.Since(cs.CreationTimestamp.Time)
-				_, _ = fmt.Fprintf(tw, "%s%s\t%s\t%s\t%s\t%s\n", cs.Name, ns, cs.Spec.DisplayName, cs.Spec.SourceType, cs.Spec.Publisher, duration.HumanDuration(age))
+				_, _ = fmt.Fprintf(tw, "%s\n", catalogRow(cs, allNamespaces))
 			}
 			_ = tw.Flush()
 		},
 	}
 	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "list catalogs in all namespaces")
+	bindWatchFlags(cmd.Flags(), &watch, "catalog sources")
 	return cmd
 }
+
+func catalogListHeader(nsCol bool) string {
+	if nsCol {
+		return "NAME\tNAMESPACE\tDISPLAY\tTYPE\tPUBLISHER\tAGE\n"
+	}
+	return "NAME\tDISPLAY\tTYPE\tPUBLISHER\tAGE\n"
+}
+
+func catalogRow(cs v1alpha1.CatalogSource, nsCol bool) string {
+	ns := ""
+	if nsCol {
+		ns = "\t" + cs.Namespace
+	}
+	age := time.Since(cs.CreationTimestamp.Time)
+	return fmt.Sprintf("%s%s\t%s\t%s\t%s\t%s", cs.Name, ns, cs.Spec.DisplayName, cs.Spec.SourceType, cs.Spec.Publisher, duration.HumanDuration(age))
+}
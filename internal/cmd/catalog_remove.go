@@ -4,12 +4,11 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
-	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
 func newCatalogRemoveCmd(cfg *action.Configuration) *cobra.Command {
-	u := internalaction.NewCatalogRemove(cfg)
+	u := action.NewCatalogRemove(cfg)
 	cmd := &cobra.Command{
 		Use:   "remove <catalog_name>",
 		Short: "Remove a operator catalog",
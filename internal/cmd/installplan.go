@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newInstallPlanCmd(cfg *action.Configuration) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "installplan",
+		Aliases: []string{"ip"},
+		Short:   "Review and approve operator install plans",
+	}
+	cmd.AddCommand(
+		newInstallPlanListCmd(cfg),
+		newInstallPlanDescribeCmd(cfg),
+		newInstallPlanApproveCmd(cfg),
+		newInstallPlanRejectCmd(cfg),
+	)
+	return cmd
+}
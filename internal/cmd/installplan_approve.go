@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newInstallPlanApproveCmd(cfg *action.Configuration) *cobra.Command {
+	a := internalaction.NewInstallPlanApprove(cfg)
+	cmd := &cobra.Command{
+		Use:   "approve <installplan>",
+		Short: "Approve an install plan",
+		Long: `Approve an install plan so that OLM runs it.
+
+Use "installplan describe" to review the steps of the install plan first.
+Install plans for a CSV outside the version constraint that the subscription
+was installed with are refused.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			a.Name = args[0]
+			ip, err := a.Run(cmd.Context())
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Printf("install plan %q approved", ip.Name)
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newInstallPlanDescribeCmd(cfg *action.Configuration) *cobra.Command {
+	d := internalaction.NewInstallPlanDescribe(cfg)
+	cmd := &cobra.Command{
+		Use:   "describe <installplan>",
+		Short: "Describe an install plan",
+		Long: `Describe an install plan.
+
+The output lists the cluster service versions the install plan installs, its
+approval state, its conditions and every step of the plan with the status of
+the step's resource. Review the steps before approving a manual install plan.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			d.Name = args[0]
+			ip, err := d.Run(cmd.Context())
+			if err != nil {
+				log.Fatal(err)
+			}
+			writeInstallPlan(os.Stdout, ip)
+		},
+	}
+	return cmd
+}
+
+func writeInstallPlan(w io.Writer, ip *v1alpha1.InstallPlan) {
+	owners := []string{}
+	for _, ref := range ip.GetOwnerReferences() {
+		if ref.Kind == v1alpha1.SubscriptionKind {
+			owners = append(owners, ref.Name)
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 3, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "Name:\t%s\n", ip.Name)
+	_, _ = fmt.Fprintf(tw, "Namespace:\t%s\n", ip.Namespace)
+	_, _ = fmt.Fprintf(tw, "Subscriptions:\t%s\n", orNone(strings.Join(owners, ", ")))
+	_, _ = fmt.Fprintf(tw, "CSVs:\t%s\n", orNone(strings.Join(ip.Spec.ClusterServiceVersionNames, ", ")))
+	_, _ = fmt.Fprintf(tw, "Approval:\t%s\n", ip.Spec.Approval)
+	_, _ = fmt.Fprintf(tw, "Approved:\t%t\n", ip.Spec.Approved)
+	_, _ = fmt.Fprintf(tw, "Phase:\t%s\n", ip.Status.Phase)
+	_ = tw.Flush()
+
+	if len(ip.Status.Conditions) > 0 {
+		_, _ = fmt.Fprintln(w, "\nConditions:")
+		tw = tabwriter.NewWriter(w, 3, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "  TYPE\tSTATUS\tREASON\tMESSAGE")
+		for _, c := range ip.Status.Conditions {
+			_, _ = fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", c.Type, c.Status, c.Reason, c.Message)
+		}
+		_ = tw.Flush()
+	}
+
+	if len(ip.Status.BundleLookups) > 0 {
+		_, _ = fmt.Fprintln(w, "\nBundle Lookups:")
+		tw = tabwriter.NewWriter(w, 3, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "  IDENTIFIER\tPATH\tCATALOG")
+		for _, b := range ip.Status.BundleLookups {
+			catalog := ""
+			if b.CatalogSourceRef != nil {
+				catalog = b.CatalogSourceRef.Namespace + "/" + b.CatalogSourceRef.Name
+			}
+			_, _ = fmt.Fprintf(tw, "  %s\t%s\t%s\n", b.Identifier, b.Path, catalog)
+		}
+		_ = tw.Flush()
+	}
+
+	_, _ = fmt.Fprintln(w, "\nSteps:")
+	if len(ip.Status.Plan) == 0 {
+		_, _ = fmt.Fprintln(w, "  <none>")
+		return
+	}
+	tw = tabwriter.NewWriter(w, 3, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "  RESOLVING\tKIND\tNAME\tAPI VERSION\tSTATUS")
+	for _, s := range ip.Status.Plan {
+		if s == nil {
+			continue
+		}
+		apiVersion := s.Resource.Version
+		if s.Resource.Group != "" {
+			apiVersion = s.Resource.Group + "/" + s.Resource.Version
+		}
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\n", s.Resolving, s.Resource.Kind, s.Resource.Name, apiVersion, s.Status)
+	}
+	_ = tw.Flush()
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newInstallPlanListCmd(cfg *action.Configuration) *cobra.Command {
+	var allNamespaces bool
+	l := internalaction.NewInstallPlanList(cfg)
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List install plans",
+		Args:  cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			if allNamespaces {
+				cfg.Namespace = corev1.NamespaceAll
+			}
+			ips, err := l.Run(cmd.Context())
+			if err != nil {
+				log.Fatalf("list install plans: %v", err)
+			}
+
+			if len(ips) == 0 {
+				if cfg.Namespace == corev1.NamespaceAll {
+					log.Print("No resources found")
+				} else {
+					log.Printf("No resources found in %s namespace.", cfg.Namespace)
+				}
+				return
+			}
+
+			sort.SliceStable(ips, func(i, j int) bool {
+				if ips[i].Namespace != ips[j].Namespace {
+					return ips[i].Namespace < ips[j].Namespace
+				}
+				return ips[i].Name < ips[j].Name
+			})
+			nsCol := ""
+			if allNamespaces {
+				nsCol = "\tNAMESPACE"
+			}
+			tw := tabwriter.NewWriter(os.Stdout, 3, 4, 2, ' ', 0)
+			_, _ = fmt.Fprintf(tw, "NAME%s\tCSV\tAPPROVAL\tAPPROVED\tPHASE\tAGE\n", nsCol)
+			for _, ip := range ips {
+				ns := ""
+				if allNamespaces {
+					ns = "\t" + ip.Namespace
+				}
+				age := time.Since(ip.CreationTimestamp.Time)
+				_, _ = fmt.Fprintf(tw, "%s%s\t%s\t%s\t%t\t%s\t%s\n", ip.Name, ns, strings.Join(ip.Spec.ClusterServiceVersionNames, ","), ip.Spec.Approval, ip.Spec.Approved, ip.Status.Phase, duration.HumanDuration(age))
+			}
+			_ = tw.Flush()
+		},
+	}
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "list install plans in all namespaces")
+	return cmd
+}
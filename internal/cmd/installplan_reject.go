@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newInstallPlanRejectCmd(cfg *action.Configuration) *cobra.Command {
+	r := internalaction.NewInstallPlanReject(cfg)
+	cmd := &cobra.Command{
+		Use:     "reject <installplan>",
+		Aliases: []string{"delete"},
+		Short:   "Reject an install plan",
+		Long: `Reject an install plan by deleting it.
+
+Install plans that are approved and still running cannot be rejected.
+
+OLM generates a new install plan for a subscription whose update is still
+pending. To stop an unwanted update, change the subscription's channel or
+starting CSV, or uninstall the operator.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			r.Name = args[0]
+			if err := r.Run(cmd.Context()); err != nil {
+				log.Fatal(err)
+			}
+			log.Printf("install plan %q rejected", r.Name)
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,80 @@
+package olmv1
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	olmv1 "github.com/operator-framework/operator-controller/api/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	experimentalaction "github.com/operator-framework/kubectl-operator/internal/pkg/experimental/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func NewOperatorDescribeCmd(cfg *action.Configuration) *cobra.Command {
+	d := experimentalaction.NewOperatorDescribe(cfg)
+	cmd := &cobra.Command{
+		Use:   "describe <operator>",
+		Short: "Describe an installed operator",
+		Long: `Describe an installed operator.
+
+The output shows the ClusterExtension's install options, the installed and
+resolved bundles and every status condition with its reason and message.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			d.Name = args[0]
+			ext, err := d.Run(cmd.Context())
+			if err != nil {
+				log.Fatal(err)
+			}
+			writeClusterExtension(os.Stdout, ext)
+		},
+	}
+	return cmd
+}
+
+func writeClusterExtension(w io.Writer, ext *olmv1.ClusterExtension) {
+	tw := tabwriter.NewWriter(w, 3, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "Name:\t%s\n", ext.Name)
+	_, _ = fmt.Fprintf(tw, "Package:\t%s\n", ext.Spec.PackageName)
+	_, _ = fmt.Fprintf(tw, "Version:\t%s\n", orAny(ext.Spec.Version))
+	_, _ = fmt.Fprintf(tw, "Channel:\t%s\n", orAny(ext.Spec.Channel))
+	_, _ = fmt.Fprintf(tw, "Upgrade Constraint Policy:\t%s\n", orDefault(string(ext.Spec.UpgradeConstraintPolicy), string(olmv1.UpgradeConstraintPolicyEnforce)))
+	_, _ = fmt.Fprintf(tw, "Install Namespace:\t%s\n", ext.Spec.InstallNamespace)
+	_, _ = fmt.Fprintf(tw, "Service Account:\t%s\n", ext.Spec.ServiceAccount.Name)
+	_, _ = fmt.Fprintf(tw, "Installed Bundle:\t%s\n", formatBundle(ext.Status.InstalledBundle))
+	_, _ = fmt.Fprintf(tw, "Resolved Bundle:\t%s\n", formatBundle(ext.Status.ResolvedBundle))
+	_ = tw.Flush()
+
+	if len(ext.Status.Conditions) > 0 {
+		_, _ = fmt.Fprintln(w, "\nConditions:")
+		tw = tabwriter.NewWriter(w, 3, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "  TYPE\tSTATUS\tREASON\tMESSAGE")
+		for _, c := range ext.Status.Conditions {
+			_, _ = fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", c.Type, c.Status, c.Reason, c.Message)
+		}
+		_ = tw.Flush()
+	}
+}
+
+func formatBundle(b *olmv1.BundleMetadata) string {
+	if b == nil {
+		return "<none>"
+	}
+	return fmt.Sprintf("%s (%s)", b.Name, b.Version)
+}
+
+func orAny(s string) string {
+	return orDefault(s, "<any>")
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
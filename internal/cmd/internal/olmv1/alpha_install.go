@@ -2,6 +2,7 @@ package olmv1
 
 import (
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
 	experimentalaction "github.com/operator-framework/kubectl-operator/internal/pkg/experimental/action"
@@ -15,7 +16,18 @@ func NewOperatorInstallCmd(cfg *action.Configuration) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "install <operator>",
 		Short: "Install an operator",
-		Args:  cobra.ExactArgs(1),
+		Long: `Install an operator by creating a ClusterExtension for its package.
+
+Before anything is created, the catalogs in the cluster are checked for the
+package and for a bundle in the requested channel and version range.
+
+The operator is installed into the install namespace, which defaults to the
+current namespace, using the permissions of the given service account in that
+namespace. The command waits until the ClusterExtension reports that the
+operator is installed, printing its Resolved and Installed conditions as they
+change.
+`,
+		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			i.Package = args[0]
 			_, err := i.Run(cmd.Context())
@@ -25,6 +37,15 @@ func NewOperatorInstallCmd(cfg *action.Configuration) *cobra.Command {
 			log.Printf("operator %q created", i.Package)
 		},
 	}
+	bindOperatorInstallFlags(cmd.Flags(), i)
 
 	return cmd
 }
+
+func bindOperatorInstallFlags(fs *pflag.FlagSet, i *experimentalaction.OperatorInstall) {
+	fs.StringVarP(&i.Version, "version", "v", "", "semver range of versions to install, e.g. \">=1.2.0 <2.0.0\" (defaults to the latest version)")
+	fs.StringVarP(&i.Channel, "channel", "c", "", "channel to install the operator from")
+	fs.StringVar(&i.InstallNamespace, "install-namespace", "", "namespace to install the operator into (defaults to the current namespace)")
+	fs.StringVar(&i.ServiceAccount, "service-account", "", "service account in the install namespace used to install and manage the operator (required)")
+	fs.StringVar(&i.UpgradeConstraintPolicy, "upgrade-constraint-policy", "", "whether upgrades must follow the package's upgrade edges, one of Enforce|Ignore (defaults to Enforce)")
+}
@@ -0,0 +1,73 @@
+package olmv1
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	olmv1 "github.com/operator-framework/operator-controller/api/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	experimentalaction "github.com/operator-framework/kubectl-operator/internal/pkg/experimental/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func NewOperatorListCmd(cfg *action.Configuration) *cobra.Command {
+	l := experimentalaction.NewOperatorList(cfg)
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List installed operators",
+		Args:  cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			exts, err := l.Run(cmd.Context())
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if len(exts) == 0 {
+				log.Print("No resources found")
+				return
+			}
+
+			tw := tabwriter.NewWriter(os.Stdout, 3, 4, 2, ' ', 0)
+			_, _ = fmt.Fprintf(tw, "NAME\tPACKAGE\tINSTALLED BUNDLE\tVERSION\tRESOLVED BUNDLE\tCONDITIONS\tAGE\n")
+			for _, ext := range exts {
+				installed, version := bundleName(ext.Status.InstalledBundle), ""
+				if ext.Status.InstalledBundle != nil {
+					version = ext.Status.InstalledBundle.Version
+				}
+				age := time.Since(ext.CreationTimestamp.Time)
+				_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", ext.Name, ext.Spec.PackageName, installed, version,
+					bundleName(ext.Status.ResolvedBundle), conditionSummary(ext.Status.Conditions), duration.HumanDuration(age))
+			}
+			_ = tw.Flush()
+		},
+	}
+	return cmd
+}
+
+func bundleName(b *olmv1.BundleMetadata) string {
+	if b == nil {
+		return ""
+	}
+	return b.Name
+}
+
+// conditionSummary renders the Resolved, Installed and Deprecated conditions
+// as a comma-separated list of Type=Status.
+func conditionSummary(conditions []metav1.Condition) string {
+	s := []string{}
+	for _, t := range []string{olmv1.TypeResolved, olmv1.TypeInstalled, olmv1.TypeDeprecated} {
+		if c := meta.FindStatusCondition(conditions, t); c != nil {
+			s = append(s, fmt.Sprintf("%s=%s", c.Type, c.Status))
+		}
+	}
+	return strings.Join(s, ",")
+}
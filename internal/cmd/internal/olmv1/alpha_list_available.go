@@ -0,0 +1,84 @@
+package olmv1
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	experimentalaction "github.com/operator-framework/kubectl-operator/internal/pkg/experimental/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func NewOperatorListAvailableCmd(cfg *action.Configuration) *cobra.Command {
+	l := experimentalaction.NewOperatorListAvailable(cfg)
+	l.Logf = log.Printf
+
+	cmd := &cobra.Command{
+		Use:   "list-available",
+		Short: "List operators available to be installed",
+		Long: `List the packages served by the catalogs in the cluster, with the
+versions available in each of their channels.`,
+		Args: cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			runListAvailable(cmd, l)
+		},
+	}
+	bindOperatorListAvailableFlags(cmd.Flags(), l)
+	return cmd
+}
+
+func NewOperatorSearchCmd(cfg *action.Configuration) *cobra.Command {
+	l := experimentalaction.NewOperatorListAvailable(cfg)
+	l.Logf = log.Printf
+
+	cmd := &cobra.Command{
+		Use:   "search <keyword>",
+		Short: "Search for operators available to be installed",
+		Long: `Search the catalogs in the cluster for packages whose name contains the
+keyword, and list the versions available in each of their channels.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			l.Keyword = args[0]
+			runListAvailable(cmd, l)
+		},
+	}
+	bindOperatorListAvailableFlags(cmd.Flags(), l)
+	return cmd
+}
+
+func bindOperatorListAvailableFlags(fs *pflag.FlagSet, l *experimentalaction.OperatorListAvailable) {
+	fs.StringVarP(&l.Catalog, "catalog", "c", "", "only list packages from this catalog")
+}
+
+func runListAvailable(cmd *cobra.Command, l *experimentalaction.OperatorListAvailable) {
+	pkgs, err := l.Run(cmd.Context())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(pkgs) == 0 {
+		log.Print("No resources found")
+		return
+	}
+	writeAvailablePackages(os.Stdout, pkgs)
+}
+
+func writeAvailablePackages(w io.Writer, pkgs []experimentalaction.AvailablePackage) {
+	tw := tabwriter.NewWriter(w, 3, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "NAME\tCATALOG\tCHANNEL\tVERSIONS\n")
+	for _, p := range pkgs {
+		for _, c := range p.Channels {
+			channel := c.Name
+			if c.Name == p.DefaultChannel {
+				channel += " (default)"
+			}
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", p.Name, p.Catalog, channel, strings.Join(c.Versions, ", "))
+		}
+	}
+	_ = tw.Flush()
+}
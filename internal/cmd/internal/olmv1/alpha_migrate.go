@@ -0,0 +1,107 @@
+package olmv1
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	experimentalaction "github.com/operator-framework/kubectl-operator/internal/pkg/experimental/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func NewOperatorMigrateCmd(cfg *action.Configuration) *cobra.Command {
+	var apply bool
+	m := experimentalaction.NewOperatorMigrate(cfg)
+	m.Logf = log.Printf
+
+	cmd := &cobra.Command{
+		Use:   "migrate <operator>",
+		Short: "Migrate an operator installed by OLMv0 to OLMv1",
+		Long: `Migrate an operator installed by an OLMv0 subscription to an OLMv1
+ClusterExtension pinned to the installed version.
+
+By default, the command only reports the migration plan and anything that
+prevents it. With --apply, it:
+
+  1. hands the operator's CRDs over to the ClusterExtension,
+  2. creates the ClusterExtension and waits for it to be installed, and
+  3. deletes the subscription and the CSV.
+
+The CRDs and the operands are never deleted. Operators that do not support
+the AllNamespaces install mode, that depend on other operators' APIs, or that
+define webhooks or API services cannot be migrated.
+
+The CSV's deployments keep running until the ClusterExtension is installed, so
+the ClusterExtension must be installed into a different namespace than the
+subscription's; use --install-namespace to choose one.
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			m.Package = args[0]
+
+			// Always report the plan before changing anything.
+			m.DryRun = true
+			plan, err := m.Run(cmd.Context())
+			if err != nil {
+				log.Fatalf("failed to plan migration: %v", err)
+			}
+			writeOperatorMigration(os.Stdout, plan)
+			if len(plan.Problems) > 0 {
+				log.Fatalf("operator %q cannot be migrated", m.Package)
+			}
+			if !apply {
+				log.Printf("operator %q can be migrated; rerun with --apply to migrate it", m.Package)
+				return
+			}
+
+			m.DryRun = false
+			plan, err = m.Run(cmd.Context())
+			if err != nil {
+				log.Fatalf("failed to migrate operator: %v", err)
+			}
+			log.Printf("operator %q migrated to clusterextension %q", m.Package, plan.ClusterExtension.Name)
+		},
+	}
+	bindOperatorMigrateFlags(cmd.Flags(), m)
+	cmd.Flags().BoolVar(&apply, "apply", false, "carry out the migration instead of only reporting it")
+	return cmd
+}
+
+func bindOperatorMigrateFlags(fs *pflag.FlagSet, m *experimentalaction.OperatorMigrate) {
+	fs.StringVar(&m.InstallNamespace, "install-namespace", "", "namespace to install the cluster extension into (defaults to the current namespace)")
+	fs.StringVar(&m.ServiceAccount, "service-account", "", "service account in the install namespace used to install and manage the operator (required)")
+}
+
+func writeOperatorMigration(w io.Writer, plan *experimentalaction.OperatorMigration) {
+	ext := plan.ClusterExtension
+	tw := tabwriter.NewWriter(w, 3, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "Subscription:\t%s/%s\n", plan.Subscription.Namespace, plan.Subscription.Name)
+	_, _ = fmt.Fprintf(tw, "CSV:\t%s\n", plan.CSV.Name)
+	_, _ = fmt.Fprintf(tw, "ClusterExtension:\t%s\n", ext.Name)
+	_, _ = fmt.Fprintf(tw, "  Package:\t%s\n", ext.Spec.PackageName)
+	_, _ = fmt.Fprintf(tw, "  Version:\t%s\n", ext.Spec.Version)
+	_, _ = fmt.Fprintf(tw, "  Channel:\t%s\n", orAny(ext.Spec.Channel))
+	_, _ = fmt.Fprintf(tw, "  Install Namespace:\t%s\n", ext.Spec.InstallNamespace)
+	_, _ = fmt.Fprintf(tw, "  Service Account:\t%s\n", orDefault(ext.Spec.ServiceAccount.Name, "<none>"))
+	_, _ = fmt.Fprintf(tw, "CRDs handed over:\t%s\n", orDefault(strings.Join(plan.CRDs, ", "), "<none>"))
+	_ = tw.Flush()
+
+	writeList(w, "Warnings", plan.Warnings)
+	writeList(w, "Problems", plan.Problems)
+}
+
+func writeList(w io.Writer, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "\n%s:\n", title)
+	for _, item := range items {
+		_, _ = fmt.Fprintf(w, "  - %s\n", item)
+	}
+}
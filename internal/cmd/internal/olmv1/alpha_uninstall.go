@@ -1,10 +1,14 @@
 package olmv1
 
 import (
+	"errors"
+
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
 	experimentalaction "github.com/operator-framework/kubectl-operator/internal/pkg/experimental/action"
+	"github.com/operator-framework/kubectl-operator/internal/pkg/operand"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
@@ -14,21 +18,42 @@ func NewOperatorUninstallCmd(cfg *action.Configuration) *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "uninstall <operator>",
-		Short: "Uninstall an operator",
-		Long: `Uninstall deletes the named Operator object.
+		Short: "Uninstall an operator and operands",
+		Long: `Uninstall deletes the named ClusterExtension.
+
+Warning: this command permanently deletes objects from the cluster. Deleting a
+ClusterExtension uninstalls its bundle, including its CRDs, which cascades to
+the deletion of all operands.
+
+The uninstall command first finds the CRDs the ClusterExtension installed and
+counts the operands of each of them throughout the cluster.
 
-Warning: this command permanently deletes objects from the cluster. If the
-uninstalled Operator bundle contains CRDs, the CRDs will be deleted, which
-cascades to the deletion of all operands.
+The operand-deletion strategy is then considered if any operands are found
+on-cluster. One of abort|ignore|delete. By default, the strategy is "abort",
+which means that if any operands are found the uninstall is aborted without
+deleting anything. The "ignore" strategy releases the CRDs from the
+ClusterExtension, deletes the ClusterExtension without garbage collecting its
+objects, and then deletes the objects of its bundle other than the CRDs, so
+that the CRDs and operands stay on the cluster. The
+"delete" strategy deletes the operands and, after they have finished
+finalizing, the ClusterExtension.
 `,
 		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			u.Package = args[0]
 			if err := u.Run(cmd.Context()); err != nil {
+				if errors.Is(err, operand.ErrAbortStrategy) {
+					log.Fatalf("uninstall operator: %v"+"\n\n%s", err,
+						"See kubectl operator olmv1 uninstall --help for more information on operand deletion strategies.")
+				}
 				log.Fatalf("uninstall operator: %v", err)
 			}
-			log.Printf("deleted operator %q", u.Package)
 		},
 	}
+	bindOperatorUninstallFlags(cmd.Flags(), u)
 	return cmd
 }
+
+func bindOperatorUninstallFlags(fs *pflag.FlagSet, u *experimentalaction.OperatorUninstall) {
+	fs.VarP(&u.OperandStrategy, "operand-strategy", "s", "determines how to handle operands when deleting the operator, one of abort|ignore|delete (default: abort)")
+}
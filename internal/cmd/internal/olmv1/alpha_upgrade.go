@@ -0,0 +1,43 @@
+package olmv1
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	experimentalaction "github.com/operator-framework/kubectl-operator/internal/pkg/experimental/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func NewOperatorUpgradeCmd(cfg *action.Configuration) *cobra.Command {
+	u := experimentalaction.NewOperatorUpgrade(cfg)
+	u.Logf = log.Printf
+
+	cmd := &cobra.Command{
+		Use:   "upgrade <operator>",
+		Short: "Upgrade an operator",
+		Long: `Upgrade an operator by changing the version range or channel of its
+ClusterExtension.
+
+The command waits until the ClusterExtension reports that a bundle matching
+the new version range is installed, printing its Resolved and Installed
+conditions as they change.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			u.Name = args[0]
+			ext, err := u.Run(cmd.Context())
+			if err != nil {
+				log.Fatalf("failed to upgrade operator: %v", err)
+			}
+			log.Printf("operator %q upgraded; installed bundle is %s", u.Name, formatBundle(ext.Status.InstalledBundle))
+		},
+	}
+	bindOperatorUpgradeFlags(cmd.Flags(), u)
+	return cmd
+}
+
+func bindOperatorUpgradeFlags(fs *pflag.FlagSet, u *experimentalaction.OperatorUpgrade) {
+	fs.StringVarP(&u.Version, "version", "v", "", "semver range of versions to upgrade to, e.g. \">=1.3.0 <2.0.0\"")
+	fs.StringVarP(&u.Channel, "channel", "c", "", "channel to upgrade from")
+	fs.StringVar(&u.UpgradeConstraintPolicy, "upgrade-constraint-policy", "", "whether the upgrade must follow the package's upgrade edges, one of Enforce|Ignore (defaults to the current policy)")
+}
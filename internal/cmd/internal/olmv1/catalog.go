@@ -0,0 +1,20 @@
+package olmv1
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func NewCatalogCmd(cfg *action.Configuration) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "catalog",
+		Short: "Manage catalogd ClusterCatalogs",
+	}
+	cmd.AddCommand(
+		newCatalogAddCmd(cfg),
+		newCatalogListCmd(cfg),
+		newCatalogRemoveCmd(cfg),
+	)
+	return cmd
+}
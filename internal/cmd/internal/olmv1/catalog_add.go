@@ -0,0 +1,42 @@
+package olmv1
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	experimentalaction "github.com/operator-framework/kubectl-operator/internal/pkg/experimental/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newCatalogAddCmd(cfg *action.Configuration) *cobra.Command {
+	a := experimentalaction.NewCatalogAdd(cfg)
+	a.Logf = log.Printf
+
+	cmd := &cobra.Command{
+		Use:   "add <name> <image_ref>",
+		Short: "Add a catalog",
+		Long: `Add a catalog by creating a ClusterCatalog for a file-based catalog image.
+
+The command waits until catalogd reports that the catalog is unpacked.
+`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			a.CatalogName = args[0]
+			a.ImageRef = args[1]
+
+			cat, err := a.Run(cmd.Context())
+			if err != nil {
+				log.Fatalf("failed to add catalog: %v", err)
+			}
+			log.Printf("clustercatalog %q unpacked from %s", cat.Name, cat.ResolvedRef())
+		},
+	}
+	bindCatalogAddFlags(cmd.Flags(), a)
+
+	return cmd
+}
+
+func bindCatalogAddFlags(fs *pflag.FlagSet, a *experimentalaction.CatalogAdd) {
+	fs.DurationVar(&a.PollInterval, "poll-interval", 0, "how often to check the image ref for a new digest (default only unpack once)")
+}
@@ -0,0 +1,55 @@
+package olmv1
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	experimentalaction "github.com/operator-framework/kubectl-operator/internal/pkg/experimental/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newCatalogListCmd(cfg *action.Configuration) *cobra.Command {
+	l := experimentalaction.NewCatalogList(cfg)
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List catalogs",
+		Args:  cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			catalogs, err := l.Run(cmd.Context())
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if len(catalogs) == 0 {
+				log.Print("No resources found")
+				return
+			}
+
+			tw := tabwriter.NewWriter(os.Stdout, 3, 4, 2, ' ', 0)
+			_, _ = fmt.Fprintf(tw, "NAME\tIMAGE\tPOLL INTERVAL\tUNPACKED\tRESOLVED REF\tAGE\n")
+			for _, cat := range catalogs {
+				image, poll := "", ""
+				if cat.Spec.Source.Image != nil {
+					image = cat.Spec.Source.Image.Ref
+					if cat.Spec.Source.Image.PollInterval != nil {
+						poll = cat.Spec.Source.Image.PollInterval.Duration.String()
+					}
+				}
+				unpacked := "Unknown"
+				if c := cat.Unpacked(); c != nil {
+					unpacked = fmt.Sprintf("%s (%s)", c.Status, c.Reason)
+				}
+				age := time.Since(cat.CreationTimestamp.Time)
+				_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", cat.Name, image, poll, unpacked, cat.ResolvedRef(), duration.HumanDuration(age))
+			}
+			_ = tw.Flush()
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,27 @@
+package olmv1
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	experimentalaction "github.com/operator-framework/kubectl-operator/internal/pkg/experimental/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newCatalogRemoveCmd(cfg *action.Configuration) *cobra.Command {
+	r := experimentalaction.NewCatalogRemove(cfg)
+	cmd := &cobra.Command{
+		Use:   "remove <catalog_name>",
+		Short: "Remove a catalog",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			r.CatalogName = args[0]
+
+			if err := r.Run(cmd.Context()); err != nil {
+				log.Fatalf("failed to remove catalog %q: %v", r.CatalogName, err)
+			}
+			log.Printf("clustercatalog %q removed", r.CatalogName)
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/spf13/pflag"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// clusterFlags select the kubeconfig contexts a command fans out to.
+type clusterFlags struct {
+	contexts    []string
+	allContexts bool
+}
+
+func bindClusterFlags(fs *pflag.FlagSet, f *clusterFlags) {
+	fs.StringSliceVar(&f.contexts, "contexts", nil, "run against each of these kubeconfig contexts in parallel")
+	fs.BoolVar(&f.allContexts, "all-contexts", false, "run against every kubeconfig context in parallel")
+}
+
+func (f clusterFlags) enabled() bool {
+	return len(f.contexts) > 0 || f.allContexts
+}
+
+// fansOut reports whether the cluster flags bound to fs select contexts to
+// fan out to. Such commands load a configuration per context, so the current
+// context need not load.
+func fansOut(fs *pflag.FlagSet) bool {
+	contexts, _ := fs.GetStringSlice("contexts")
+	allContexts, _ := fs.GetBool("all-contexts")
+	return clusterFlags{contexts: contexts, allContexts: allContexts}.enabled()
+}
+
+// clusterResult is the outcome of running a command against one cluster.
+type clusterResult[T any] struct {
+	Context string
+	Value   T
+	Err     error
+}
+
+// runOnClusters loads a configuration for each selected context and runs fn
+// against all of them in parallel. An error on one cluster, including a
+// context that fails to load, does not stop the others; results are returned
+// in context order.
+func runOnClusters[T any](ctx context.Context, cfg *action.Configuration, f clusterFlags, fn func(context.Context, *action.Configuration) (T, error)) []clusterResult[T] {
+	var names []string
+	if !f.allContexts {
+		names = f.contexts
+	}
+	cfgs, loadErrs, err := cfg.LoadContexts(names)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	results := make([]clusterResult[T], len(cfgs), len(cfgs)+len(loadErrs))
+	var wg sync.WaitGroup
+	for i, c := range cfgs {
+		wg.Add(1)
+		go func(i int, c *action.Configuration) {
+			defer wg.Done()
+			v, err := fn(ctx, c)
+			results[i] = clusterResult[T]{Context: c.Context, Value: v, Err: err}
+		}(i, c)
+	}
+	wg.Wait()
+
+	for name, err := range loadErrs {
+		results = append(results, clusterResult[T]{Context: name, Err: err})
+	}
+	order := map[string]int{}
+	for i, name := range names {
+		order[name] = i
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if names == nil {
+			return results[i].Context < results[j].Context
+		}
+		return order[results[i].Context] < order[results[j].Context]
+	})
+	return results
+}
+
+// logClusterErrors logs the error of each failed cluster and returns how many
+// clusters failed.
+func logClusterErrors[T any](results []clusterResult[T]) int {
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			log.Printf("cluster %q: %v", r.Context, r.Err)
+			failed++
+		}
+	}
+	return failed
+}
+
+// clusterLogf prefixes log lines with the cluster's context name, so the
+// interleaved output of parallel runs can be told apart.
+func clusterLogf(context string) func(string, ...interface{}) {
+	return func(format string, args ...interface{}) {
+		log.Printf("[%s] "+format, append([]interface{}{context}, args...)...)
+	}
+}
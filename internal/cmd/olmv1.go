@@ -17,6 +17,13 @@ func newOlmV1Cmd(cfg *action.Configuration) *cobra.Command {
 	cmd.AddCommand(
 		olmv1.NewOperatorInstallCmd(cfg),
 		olmv1.NewOperatorUninstallCmd(cfg),
+		olmv1.NewOperatorListCmd(cfg),
+		olmv1.NewOperatorDescribeCmd(cfg),
+		olmv1.NewOperatorUpgradeCmd(cfg),
+		olmv1.NewOperatorListAvailableCmd(cfg),
+		olmv1.NewOperatorSearchCmd(cfg),
+		olmv1.NewOperatorMigrateCmd(cfg),
+		olmv1.NewCatalogCmd(cfg),
 	)
 
 	return cmd
@@ -2,11 +2,19 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+	"github.com/operator-framework/operator-registry/pkg/image/containerdregistry"
+
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
 	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
 	"github.com/operator-framework/kubectl-operator/internal/pkg/operator"
@@ -22,40 +30,77 @@ var (
 	imHdr   = asHeader("Install Modes")
 	sdHdr   = asHeader("Description")
 	ldHdr   = asHeader("Long Description")
+	capHdr  = asHeader("Capability Level")
+	matHdr  = asHeader("Maturity")
+	mkvHdr  = asHeader("Minimum Kubernetes Version")
+	kwHdr   = asHeader("Keywords")
+	lnkHdr  = asHeader("Links")
+	ownHdr  = asHeader("Owned APIs")
+	reqHdr  = asHeader("Required APIs")
+	cpHdr   = asHeader("Cluster Permissions")
+	npHdr   = asHeader("Namespaced Permissions")
+	riHdr   = asHeader("Related Images")
 
 	repoAnnot = "repository"
 	descAnnot = "description"
+	capAnnot  = "capabilities"
+
+	// noBundleData is shown for sections that packageserver never serves
+	noBundleData = "<not served by packageserver; use --with-bundle-data>"
 )
 
 func newOperatorDescribeCmd(cfg *action.Configuration) *cobra.Command {
-	l := internalaction.NewOperatorListAvailable(cfg)
+	d := internalaction.NewOperatorDescribe(cfg)
+	d.Logf = log.Printf
 	// receivers for cmdline flags
-	var channel string
 	var longDescription bool
+	var graphFormat string
 
 	cmd := &cobra.Command{
 		Use:   "describe <operator>",
 		Short: "Describe an operator",
-		Args:  cobra.ExactArgs(1),
+		Long: `Describe an operator available in the cluster's catalogs.
+
+Details are read from the channel head served by packageserver. Some details,
+such as the permissions the operator requests, are not served by packageserver.
+Use --with-bundle-data to read them from the bundle in the catalog's index
+image. This pulls the index image, so it may take a while.
+
+Use --graph to show the upgrade graph of every channel of the operator instead.
+The graph format is one of tree|dot|mermaid (default: tree). Packageserver
+only serves the entries of each channel; to show replaces and skips edges,
+combine --graph with --with-bundle-data. The channel head and the version
+installed in the namespace are marked.`,
+		Args: cobra.ExactArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			regLogger := logrus.New()
+			regLogger.SetOutput(io.Discard)
+			d.RegistryOptions = []containerdregistry.RegistryOption{
+				containerdregistry.WithLog(logrus.NewEntry(regLogger)),
+			}
+		},
 		Run: func(cmd *cobra.Command, args []string) {
 			// the operator to show details about, provided by the user
-			l.Package = args[0]
+			d.Package = args[0]
+			d.WithGraph = graphFormat != ""
+			if d.WithGraph && !sets.New(validGraphFormats...).Has(graphFormat) {
+				log.Fatalf("invalid value for flag graph %q, expected one of %s", graphFormat, strings.Join(validGraphFormats, "|"))
+			}
 
 			// Find the package manifest and package channel for the operator
-			pms, err := l.Run(cmd.Context())
+			desc, err := d.Run(cmd.Context())
 			if err != nil {
 				log.Fatal(err)
 			}
 
-			// we only expect one item because describe always searches
-			// for a specific operator by name
-			pm := pms[0]
-
-			pc, err := pm.GetChannel(channel)
-			if err != nil {
-				// the requested channel doesn't exist
-				log.Fatal(err)
+			// if the user requested the upgrade graph, show only that
+			if d.WithGraph {
+				if err := writeGraphs(os.Stdout, graphFormat, desc.Graphs, desc.InstalledCSV); err != nil {
+					log.Fatal(err)
+				}
+				return
 			}
+			pm, pc, cd := desc.PackageManifest, desc.Channel, desc.CSVDesc
 
 			// prepare what we want to print to the console
 			out := make([]string, 0)
@@ -64,29 +109,57 @@ func newOperatorDescribeCmd(cfg *action.Configuration) *cobra.Command {
 			out = append(out,
 				// package
 				pkgHdr+fmt.Sprintf("%s %s (by %s)\n\n",
-					pc.CurrentCSVDesc.DisplayName,
-					pc.CurrentCSVDesc.Version,
-					pc.CurrentCSVDesc.Provider.Name),
+					cd.DisplayName,
+					cd.Version,
+					cd.Provider.Name),
 				// repo
 				repoHdr+fmt.Sprintf("%s\n\n",
-					pc.CurrentCSVDesc.Annotations[repoAnnot]),
+					cd.Annotations[repoAnnot]),
 				// catalog
 				catHdr+fmt.Sprintf("%s\n\n", pm.Status.CatalogSourceDisplayName),
 				// available channels
 				chHdr+fmt.Sprintf("%s\n\n",
-					strings.Join(getAvailableChannelsWithMarkers(*pc, pm), "\n")),
+					strings.Join(getAvailableChannelsWithMarkers(pc, pm), "\n")),
 				// install modes
 				imHdr+fmt.Sprintf("%s\n\n",
 					strings.Join(sets.List[string](pc.GetSupportedInstallModes()), "\n")),
+				// capability level, maturity and minimum kubernetes version
+				capHdr+fmt.Sprintf("%s\n\n", orNone(cd.Annotations[capAnnot])),
+				matHdr+fmt.Sprintf("%s\n\n", orNone(cd.Maturity)),
+				mkvHdr+fmt.Sprintf("%s\n\n", orNone(cd.MinKubeVersion)),
+				// keywords and links
+				kwHdr+fmt.Sprintf("%s\n\n", orNone(strings.Join(cd.Keywords, ", "))),
+				lnkHdr+fmt.Sprintf("%s\n\n", orNone(strings.Join(formatLinks(cd), "\n"))),
+				// owned and required APIs
+				ownHdr+fmt.Sprintf("%s\n\n", orNone(strings.Join(formatOwnedAPIs(cd), "\n"))),
+				reqHdr+fmt.Sprintf("%s\n\n", orNone(strings.Join(formatRequiredAPIs(cd), "\n"))),
+			)
+
+			// permissions are only available in the bundle data
+			if desc.HasBundleData {
+				out = append(out,
+					cpHdr+fmt.Sprintf("%s\n\n", orNone(strings.Join(formatPermissions(desc.ClusterPermissions), "\n"))),
+					npHdr+fmt.Sprintf("%s\n\n", orNone(strings.Join(formatPermissions(desc.Permissions), "\n"))),
+				)
+			} else {
+				out = append(out,
+					cpHdr+noBundleData+"\n\n",
+					npHdr+noBundleData+"\n\n",
+				)
+			}
+
+			out = append(out,
+				// related images
+				riHdr+fmt.Sprintf("%s\n\n", orNone(strings.Join(cd.RelatedImages, "\n"))),
 				// description
 				sdHdr+fmt.Sprintf("%s\n",
-					pc.CurrentCSVDesc.Annotations[descAnnot]),
+					cd.Annotations[descAnnot]),
 			)
 
 			// if the user requested a long description, add it to the output as well
 			if longDescription {
 				out = append(out,
-					"\n"+ldHdr+pm.Status.Channels[0].CurrentCSVDesc.LongDescription)
+					"\n"+ldHdr+cd.LongDescription)
 			}
 
 			// finally, print operator information to the console
@@ -97,9 +170,12 @@ func newOperatorDescribeCmd(cfg *action.Configuration) *cobra.Command {
 	}
 
 	// add flags to the flagset for this command.
-	bindOperatorListAvailableFlags(cmd.Flags(), l)
-	cmd.Flags().StringVarP(&channel, "channel", "C", "", "package channel to describe")
+	cmd.Flags().VarP(&d.Catalog, "catalog", "c", "catalog to query (default: search all cluster catalogs)")
+	cmd.Flags().StringVarP(&d.Channel, "channel", "C", "", "package channel to describe")
 	cmd.Flags().BoolVarP(&longDescription, "with-long-description", "L", false, "include long description")
+	cmd.Flags().BoolVarP(&d.WithBundleData, "with-bundle-data", "B", false, "read details not served by packageserver, such as permissions, from the catalog's bundle data")
+	cmd.Flags().StringVar(&graphFormat, "graph", "", fmt.Sprintf("show the upgrade graph of each channel. One of: %s", strings.Join(validGraphFormats, "|")))
+	cmd.Flags().Lookup("graph").NoOptDefVal = graphFormatTree
 
 	return cmd
 }
@@ -128,3 +204,89 @@ func getAvailableChannelsWithMarkers(channel operator.PackageChannel, pm operato
 
 	return channels
 }
+
+// orNone returns s, or a placeholder if s is empty.
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+func formatLinks(cd operatorsv1.CSVDescription) []string {
+	links := make([]string, 0, len(cd.Links))
+	for _, l := range cd.Links {
+		links = append(links, fmt.Sprintf("%s: %s", l.Name, l.URL))
+	}
+	return links
+}
+
+func formatOwnedAPIs(cd operatorsv1.CSVDescription) []string {
+	apis := make([]string, 0, len(cd.CustomResourceDefinitions.Owned)+len(cd.APIServiceDefinitions.Owned))
+	for _, crd := range cd.CustomResourceDefinitions.Owned {
+		apis = append(apis, formatCRDDescription(crd))
+	}
+	for _, svc := range cd.APIServiceDefinitions.Owned {
+		apis = append(apis, formatAPIServiceDescription(svc))
+	}
+	return apis
+}
+
+func formatRequiredAPIs(cd operatorsv1.CSVDescription) []string {
+	apis := make([]string, 0, len(cd.CustomResourceDefinitions.Required)+len(cd.APIServiceDefinitions.Required)+len(cd.NativeAPIs))
+	for _, crd := range cd.CustomResourceDefinitions.Required {
+		apis = append(apis, formatCRDDescription(crd))
+	}
+	for _, svc := range cd.APIServiceDefinitions.Required {
+		apis = append(apis, formatAPIServiceDescription(svc))
+	}
+	for _, gvk := range cd.NativeAPIs {
+		apis = append(apis, fmt.Sprintf("%s (%s/%s, native)", gvk.Kind, gvk.Group, gvk.Version))
+	}
+	return apis
+}
+
+func formatCRDDescription(crd v1alpha1.CRDDescription) string {
+	return fmt.Sprintf("%s (%s, %s)", crd.Kind, crd.Name, crd.Version)
+}
+
+func formatAPIServiceDescription(svc v1alpha1.APIServiceDescription) string {
+	return fmt.Sprintf("%s (%s/%s, apiservice)", svc.Kind, svc.Group, svc.Version)
+}
+
+// formatPermissions renders the RBAC rules for each service account, one rule per line.
+func formatPermissions(perms []v1alpha1.StrategyDeploymentPermissions) []string {
+	lines := make([]string, 0, len(perms))
+	for _, p := range perms {
+		lines = append(lines, fmt.Sprintf("serviceaccount %s:", p.ServiceAccountName))
+		for _, r := range p.Rules {
+			lines = append(lines, "  "+formatPolicyRule(r))
+		}
+	}
+	return lines
+}
+
+func formatPolicyRule(r rbacv1.PolicyRule) string {
+	parts := []string{}
+	if len(r.APIGroups) > 0 {
+		groups := make([]string, len(r.APIGroups))
+		for i, g := range r.APIGroups {
+			if g == "" {
+				g = `""`
+			}
+			groups[i] = g
+		}
+		parts = append(parts, "apiGroups="+strings.Join(groups, ","))
+	}
+	if len(r.Resources) > 0 {
+		parts = append(parts, "resources="+strings.Join(r.Resources, ","))
+	}
+	if len(r.ResourceNames) > 0 {
+		parts = append(parts, "resourceNames="+strings.Join(r.ResourceNames, ","))
+	}
+	if len(r.NonResourceURLs) > 0 {
+		parts = append(parts, "nonResourceURLs="+strings.Join(r.NonResourceURLs, ","))
+	}
+	parts = append(parts, "verbs="+strings.Join(r.Verbs, ","))
+	return strings.Join(parts, " ")
+}
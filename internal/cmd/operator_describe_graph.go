@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/operator"
+)
+
+const (
+	graphFormatTree    = "tree"
+	graphFormatDOT     = "dot"
+	graphFormatMermaid = "mermaid"
+)
+
+var validGraphFormats = []string{graphFormatTree, graphFormatDOT, graphFormatMermaid}
+
+func writeGraphs(w io.Writer, format string, graphs []operator.ChannelGraph, installedCSV string) error {
+	switch format {
+	case graphFormatTree:
+		return writeGraphTree(w, graphs, installedCSV)
+	case graphFormatDOT:
+		return writeGraphDOT(w, graphs, installedCSV)
+	case graphFormatMermaid:
+		return writeGraphMermaid(w, graphs, installedCSV)
+	}
+	return fmt.Errorf("invalid graph format %q, expected one of %s", format, strings.Join(validGraphFormats, "|"))
+}
+
+// graphNodeLabel returns the display label of a channel entry, with markers
+// for the channel head and the installed CSV.
+func graphNodeLabel(g operator.ChannelGraph, e operator.ChannelGraphEntry, installedCSV string) string {
+	label := e.Name
+	if e.Version != "" {
+		label += fmt.Sprintf(" (%s)", e.Version)
+	}
+	if e.Name == g.Head {
+		label += " [head]"
+	}
+	if e.Name == installedCSV {
+		label += " [installed]"
+	}
+	return label
+}
+
+func graphTitle(g operator.ChannelGraph) string {
+	title := g.Channel
+	if g.Default {
+		title += " (default)"
+	}
+	return title
+}
+
+// writeGraphTree writes each channel as a tree rooted at the channel head,
+// following replaces edges. Entries that are not reachable from the head are
+// written as additional roots.
+func writeGraphTree(w io.Writer, graphs []operator.ChannelGraph, installedCSV string) error {
+	var b strings.Builder
+	for i, g := range graphs {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(asHeader("Channel " + graphTitle(g)))
+		if !g.HasEdges {
+			b.WriteString("upgrade edges not served by packageserver; use --with-bundle-data to show them\n")
+			for _, e := range g.Entries {
+				b.WriteString(graphNodeLabel(g, e, installedCSV) + "\n")
+			}
+			continue
+		}
+
+		visited := map[string]bool{}
+		roots := []string{g.Head}
+		for _, e := range g.Entries {
+			if e.Name != g.Head {
+				roots = append(roots, e.Name)
+			}
+		}
+		for _, root := range roots {
+			if visited[root] {
+				continue
+			}
+			writeTreeNode(&b, g, root, "", "", visited, installedCSV)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeTreeNode(b *strings.Builder, g operator.ChannelGraph, name, prefix, edge string, visited map[string]bool, installedCSV string) {
+	e, ok := g.Entry(name)
+	if !ok {
+		// edges may point at bundles that are no longer in the channel
+		e = operator.ChannelGraphEntry{Name: name}
+	}
+	b.WriteString(edge + graphNodeLabel(g, e, installedCSV))
+	if e.SkipRange != "" {
+		b.WriteString(fmt.Sprintf(" skipRange=%q", e.SkipRange))
+	}
+	b.WriteString("\n")
+	if visited[name] || !ok {
+		return
+	}
+	visited[name] = true
+
+	type child struct {
+		kind string
+		name string
+	}
+	children := make([]child, 0, len(e.Skips)+1)
+	skips := append([]string{}, e.Skips...)
+	sort.Strings(skips)
+	for _, s := range skips {
+		children = append(children, child{"skips", s})
+	}
+	if e.Replaces != "" {
+		children = append(children, child{"replaces", e.Replaces})
+	}
+	for i, c := range children {
+		branch, indent := "├── ", "│   "
+		if i == len(children)-1 {
+			branch, indent = "└── ", "    "
+		}
+		if c.kind == "skips" {
+			// skipped entries are leaves; their own edges are shown where they are replaced
+			b.WriteString(prefix + branch + "skips " + c.name + "\n")
+			continue
+		}
+		writeTreeNode(b, g, c.name, prefix+indent, prefix+branch+"replaces ", visited, installedCSV)
+	}
+}
+
+// graphNodeID returns an identifier that is unique across channels, since the
+// same bundle may be an entry in several channels.
+func graphNodeID(g operator.ChannelGraph, name string) string {
+	return fmt.Sprintf("%q", g.Channel+"/"+name)
+}
+
+func writeGraphDOT(w io.Writer, graphs []operator.ChannelGraph, installedCSV string) error {
+	var b strings.Builder
+	b.WriteString("digraph upgrades {\n")
+	b.WriteString("  rankdir=BT;\n")
+	for i, g := range graphs {
+		b.WriteString(fmt.Sprintf("  subgraph cluster_%d {\n", i))
+		b.WriteString(fmt.Sprintf("    label=%q;\n", graphTitle(g)))
+		for _, e := range g.Entries {
+			attrs := fmt.Sprintf("label=%q", graphNodeLabel(g, e, installedCSV))
+			if e.Name == installedCSV {
+				attrs += ", style=filled, fillcolor=lightblue"
+			}
+			if e.Name == g.Head {
+				attrs += ", shape=doubleoctagon"
+			}
+			b.WriteString(fmt.Sprintf("    %s [%s];\n", graphNodeID(g, e.Name), attrs))
+		}
+		for _, e := range g.Entries {
+			if e.Replaces != "" {
+				b.WriteString(fmt.Sprintf("    %s -> %s [label=\"replaces\"];\n", graphNodeID(g, e.Name), graphNodeID(g, e.Replaces)))
+			}
+			for _, s := range e.Skips {
+				b.WriteString(fmt.Sprintf("    %s -> %s [label=\"skips\", style=dashed];\n", graphNodeID(g, e.Name), graphNodeID(g, s)))
+			}
+		}
+		b.WriteString("  }\n")
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeGraphMermaid(w io.Writer, graphs []operator.ChannelGraph, installedCSV string) error {
+	var b strings.Builder
+	b.WriteString("graph BT\n")
+	ids := map[string]string{}
+	nodeID := func(g operator.ChannelGraph, name string) string {
+		key := g.Channel + "/" + name
+		if id, ok := ids[key]; ok {
+			return id
+		}
+		id := fmt.Sprintf("n%d", len(ids))
+		ids[key] = id
+		return id
+	}
+	installed := []string{}
+	for i, g := range graphs {
+		b.WriteString(fmt.Sprintf("  subgraph c%d [%q]\n", i, graphTitle(g)))
+		for _, e := range g.Entries {
+			id := nodeID(g, e.Name)
+			b.WriteString(fmt.Sprintf("    %s[%q]\n", id, graphNodeLabel(g, e, installedCSV)))
+			if e.Name == installedCSV {
+				installed = append(installed, id)
+			}
+		}
+		for _, e := range g.Entries {
+			if e.Replaces != "" {
+				b.WriteString(fmt.Sprintf("    %s -->|replaces| %s\n", nodeID(g, e.Name), nodeID(g, e.Replaces)))
+			}
+			for _, s := range e.Skips {
+				b.WriteString(fmt.Sprintf("    %s -.->|skips| %s\n", nodeID(g, e.Name), nodeID(g, s)))
+			}
+		}
+		b.WriteString("  end\n")
+	}
+	if len(installed) > 0 {
+		b.WriteString("  classDef installed fill:#add8e6\n")
+		b.WriteString(fmt.Sprintf("  class %s installed\n", strings.Join(installed, ",")))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newOperatorDoctorCmd(cfg *action.Configuration) *cobra.Command {
+	d := internalaction.NewOperatorDoctor(cfg)
+	cmd := &cobra.Command{
+		Use:   "doctor <operator>",
+		Short: "Diagnose why an operator install or upgrade is stuck",
+		Long: `Diagnose why an operator install or upgrade is stuck.
+
+The doctor command finds the subscription for the operator and checks:
+
+  - the subscription's ResolutionFailed, CatalogSourcesUnhealthy,
+    InstallPlanPending and related conditions
+  - the connection state of the subscription's catalog source
+  - the phase and approval of the current install plan
+  - the number of operator groups in the namespace and whether the operator
+    group is compatible with the install modes the operator supports
+  - the phase and requirement status of the cluster service version
+  - the status of the pods of the operator's deployments
+
+For each problem found, it prints the likely cause and a suggested fix. The
+command exits with a non-zero status if any errors are found.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			d.Package = args[0]
+			findings, err := d.Run(cmd.Context())
+			if err != nil {
+				log.Fatalf("diagnose operator: %v", err)
+			}
+
+			errs := 0
+			for _, f := range findings {
+				if f.Severity == internalaction.SeverityError {
+					errs++
+				}
+				log.Printf("[%s] %s: %s", f.Severity, f.Check, f.Cause)
+				if f.Fix != "" {
+					log.Printf("    fix: %s", f.Fix)
+				}
+			}
+			if errs > 0 {
+				log.Fatalf("\nfound %d error(s) for operator %q", errs, d.Package)
+			}
+		},
+	}
+	return cmd
+}
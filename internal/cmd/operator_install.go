@@ -1,29 +1,125 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"time"
+	"io"
+	"os"
+	"strings"
 
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-registry/pkg/image/containerdregistry"
 
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
 	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/internal/pkg/subscription"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
 func newOperatorInstallCmd(cfg *action.Configuration) *cobra.Command {
-	i := internalaction.NewOperatorInstall(cfg)
+	i := action.NewOperatorInstall(cfg)
 	i.Logf = log.Printf
+	p := internalaction.NewOperatorPermissions(cfg)
+	p.Logf = log.Printf
+	var (
+		previewPermissions, generateRBAC bool
+		clusters                         clusterFlags
+	)
 
 	cmd := &cobra.Command{
 		Use:   "install <operator>",
 		Short: "Install an operator",
-		Args:  cobra.ExactArgs(1),
+		Long: `Install an operator.
+
+The command waits until the operator's cluster service version has succeeded,
+and fails if it fails.
+
+The --version flag accepts either an exact version from the channel or a semver
+constraint, such as ">=1.4 <2.0" or "~1.4". A constraint is resolved to the
+highest matching version in the channel. Constrained installs always use manual
+approval, and 'kubectl operator upgrade' refuses to approve install plans for
+versions outside of the constraint.
+
+For least-privilege installs, use --service-account to have OLM install the
+operator with a service account set on the operator group. The service
+account is set on an operator group created with --create-operator-group, and
+must match the service account of an existing operator group. Use
+--create-service-account to create the service account if it does not exist.
+
+The service account needs permission to create everything the operator
+installs. Use --preview-permissions to show the permissions the operator
+requests, or --generate-rbac to print a service account, roles and bindings
+that allow the install. Both read the operator's bundle from the catalog's
+index image and exit without installing.
+
+If the package is served by more than one catalog, choose one with
+--catalog [namespace/]name. The namespace may be omitted if the catalog name is
+unique. Without --catalog, installing a package that already has a
+subscription keeps the subscription's catalog.
+
+Installing a package that already has a subscription in the namespace is not
+an error. If the subscription matches the requested channel, source and
+version, and the approval if --approval is given, install waits for it and
+reports its CSV. If it differs, install fails and prints the differences; use
+--update to patch the subscription instead.
+
+Use --contexts or --all-contexts to install the operator on several clusters
+in parallel. A failed install on one cluster does not stop the others; the
+failures are reported at the end.`,
+		Args: cobra.ExactArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			regLogger := logrus.New()
+			regLogger.SetOutput(io.Discard)
+			p.RegistryOptions = []containerdregistry.RegistryOption{
+				containerdregistry.WithLog(logrus.NewEntry(regLogger)),
+			}
+		},
 		Run: func(cmd *cobra.Command, args []string) {
 			i.Package = args[0]
+			if clusters.enabled() {
+				if previewPermissions || generateRBAC {
+					log.Fatal("--preview-permissions and --generate-rbac cannot be used with --contexts or --all-contexts")
+				}
+				results := runOnClusters(cmd.Context(), cfg, clusters, func(ctx context.Context, c *action.Configuration) (*v1alpha1.ClusterServiceVersion, error) {
+					ci := action.NewOperatorInstall(c)
+					ci.InstallOptions = i.InstallOptions
+					ci.Logf = clusterLogf(c.Context)
+					return ci.Run(ctx)
+				})
+				for _, r := range results {
+					if r.Err == nil {
+						clusterLogf(r.Context)("operator %q installed; installed csv is %q", i.Package, r.Value.Name)
+					}
+				}
+				if failed := logClusterErrors(results); failed > 0 {
+					log.Fatalf("failed to install operator on %d of %d clusters", failed, len(results))
+				}
+				return
+			}
+			if previewPermissions || generateRBAC {
+				if generateRBAC && i.ServiceAccount == "" {
+					log.Fatal("--generate-rbac requires --service-account")
+				}
+				p.Package, p.Catalog, p.Channel, p.Version = i.Package, i.Catalog, i.Channel, i.Version
+				perms, err := p.Run(cmd.Context())
+				if err != nil {
+					log.Fatalf("failed to read operator permissions: %v", err)
+				}
+				if generateRBAC {
+					if err := writeRBAC(os.Stdout, perms.RBAC(cfg.Namespace, i.ServiceAccount)); err != nil {
+						log.Fatal(err)
+					}
+					return
+				}
+				writeInstallPermissions(os.Stdout, perms)
+				return
+			}
 			csv, err := i.Run(cmd.Context())
 			if err != nil {
 				log.Fatalf("failed to install operator: %v", err)
@@ -32,15 +128,47 @@ func newOperatorInstallCmd(cfg *action.Configuration) *cobra.Command {
 		},
 	}
 	bindOperatorInstallFlags(cmd.Flags(), i)
+	bindClusterFlags(cmd.Flags(), &clusters)
+	cmd.Flags().BoolVar(&previewPermissions, "preview-permissions", false, "show the permissions the operator requests and exit without installing")
+	cmd.Flags().BoolVar(&generateRBAC, "generate-rbac", false, "print RBAC that lets --service-account install the operator and exit without installing")
 
 	return cmd
 }
 
-func bindOperatorInstallFlags(fs *pflag.FlagSet, i *internalaction.OperatorInstall) {
+func bindOperatorInstallFlags(fs *pflag.FlagSet, i *action.OperatorInstall) {
 	fs.StringVarP(&i.Channel, "channel", "c", "", "subscription channel")
-	fs.VarP(&i.Approval, "approval", "a", fmt.Sprintf("approval (%s or %s)", v1alpha1.ApprovalManual, v1alpha1.ApprovalAutomatic))
-	fs.StringVarP(&i.Version, "version", "v", "", "install specific version for operator (default latest)")
+	fs.Var(&i.Catalog, "catalog", "catalog to install from, as [namespace/]name (required if several catalogs serve the package)")
+	fs.VarP((*subscription.ApprovalValue)(&i.Approval), "approval", "a", fmt.Sprintf("approval (%s or %s)", v1alpha1.ApprovalManual, v1alpha1.ApprovalAutomatic))
+	fs.StringVarP(&i.Version, "version", "v", "", "install specific version or semver constraint for operator (default latest)")
 	fs.StringSliceVarP(&i.WatchNamespaces, "watch", "w", []string{}, "namespaces to watch")
-	fs.DurationVar(&i.CleanupTimeout, "cleanup-timeout", time.Minute, "the amount of time to wait before cancelling cleanup")
 	fs.BoolVarP(&i.CreateOperatorGroup, "create-operator-group", "C", false, "create operator group if necessary")
+	fs.StringVar(&i.ServiceAccount, "service-account", "", "service account OLM uses to install the operator, set on the operator group")
+	fs.BoolVar(&i.CreateServiceAccount, "create-service-account", false, "create the service account if necessary")
+	fs.BoolVar(&i.Update, "update", false, "update an existing subscription for the package to the requested settings")
+}
+
+func writeInstallPermissions(w io.Writer, perms *internalaction.InstallPermissions) {
+	crds := make([]string, 0, len(perms.OwnedCRDs))
+	for _, crd := range perms.OwnedCRDs {
+		crds = append(crds, crd.Name)
+	}
+	_, _ = fmt.Fprint(w,
+		asHeader("CSV")+perms.CSVName+"\n\n",
+		cpHdr+orNone(strings.Join(formatPermissions(perms.ClusterPermissions), "\n"))+"\n\n",
+		npHdr+orNone(strings.Join(formatPermissions(perms.Permissions), "\n"))+"\n\n",
+		asHeader("Owned CRDs")+orNone(strings.Join(crds, "\n"))+"\n",
+	)
+}
+
+func writeRBAC(w io.Writer, objs []client.Object) error {
+	for _, obj := range objs {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("marshal %s %q: %v", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", data); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
@@ -11,57 +13,130 @@ import (
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
-	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
 func newOperatorListCmd(cfg *action.Configuration) *cobra.Command {
-	var allNamespaces bool
-	l := internalaction.NewOperatorList(cfg)
+	var (
+		allNamespaces bool
+		clusters      clusterFlags
+		watch         watchFlags
+	)
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List installed operators",
-		Args:  cobra.ExactArgs(0),
+		Long: `List installed operators.
+
+Use --contexts or --all-contexts to list the operators of several clusters in
+parallel. The output then gets a CLUSTER column, and clusters that cannot be
+listed are reported after the table.
+
+Use --watch to keep printing subscriptions as they are added, change or are
+deleted, like 'kubectl get --watch'. Each row starts with the event type. With
+--output json, each event is printed as a JSON watch event on its own line.
+Only subscriptions are watched: a change to an operator's CSV or catalog
+source is printed once OLM updates the subscription's status. Watching runs
+until interrupted and cannot be combined with --contexts or --all-contexts.`,
+		Args: cobra.ExactArgs(0),
 		Run: func(cmd *cobra.Command, args []string) {
-			if allNamespaces {
-				cfg.Namespace = corev1.NamespaceAll
-			}
-			subs, err := l.Run(cmd.Context())
-			if err != nil {
-				log.Fatalf("list operators: %v", err)
+			if err := watch.validate(); err != nil {
+				log.Fatal(err)
 			}
-
-			if len(subs) == 0 {
-				if cfg.Namespace == corev1.NamespaceAll {
-					log.Print("No resources found")
-				} else {
-					log.Printf("No resources found in %s namespace.", cfg.Namespace)
+			if watch.watch {
+				if clusters.enabled() {
+					log.Fatal("--watch cannot be used with --contexts or --all-contexts")
+				}
+				if allNamespaces {
+					cfg.Namespace = corev1.NamespaceAll
 				}
+				row := func(obj client.Object) string {
+					return operatorRow(*obj.(*v1alpha1.Subscription), allNamespaces)
+				}
+				runWatch(cmd, watch.output, operatorListHeader(allNamespaces), row, action.NewOperatorList(cfg).Watch)
 				return
 			}
 
-			sort.SliceStable(subs, func(i, j int) bool {
-				return strings.Compare(subs[i].Spec.Package, subs[j].Spec.Package) < 0
-			})
-			nsCol := ""
-			if allNamespaces {
-				nsCol = "\tNAMESPACE"
-			}
-			tw := tabwriter.NewWriter(os.Stdout, 3, 4, 2, ' ', 0)
-			_, _ = fmt.Fprintf(tw, "PACKAGE%s\tSUBSCRIPTION\tINSTALLED CSV\tCURRENT CSV\tSTATUS\tAGE\n", nsCol)
-			for _, sub := range subs {
-				ns := ""
+			list := func(ctx context.Context, c *action.Configuration) ([]v1alpha1.Subscription, error) {
 				if allNamespaces {
-					ns = "\t" + sub.Namespace
+					c.Namespace = corev1.NamespaceAll
+				}
+				subs, err := action.NewOperatorList(c).Run(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("list operators: %v", err)
+				}
+				return subs, nil
+			}
+
+			if !clusters.enabled() {
+				subs, err := list(cmd.Context(), cfg)
+				if err != nil {
+					log.Fatal(err)
+				}
+				if len(subs) == 0 {
+					if cfg.Namespace == corev1.NamespaceAll {
+						log.Print("No resources found")
+					} else {
+						log.Printf("No resources found in %s namespace.", cfg.Namespace)
+					}
+					return
 				}
-				age := time.Since(sub.CreationTimestamp.Time)
-				_, _ = fmt.Fprintf(tw, "%s%s\t%s\t%s\t%s\t%s\t%s\n", sub.Spec.Package, ns, sub.Name, sub.Status.InstalledCSV, sub.Status.CurrentCSV, sub.Status.State, duration.HumanDuration(age))
+				writeOperatorList(os.Stdout, []clusterResult[[]v1alpha1.Subscription]{{Value: subs}}, false, allNamespaces)
+				return
+			}
+
+			results := runOnClusters(cmd.Context(), cfg, clusters, list)
+			writeOperatorList(os.Stdout, results, true, allNamespaces)
+			if failed := logClusterErrors(results); failed > 0 {
+				log.Fatalf("%d of %d clusters failed", failed, len(results))
 			}
-			_ = tw.Flush()
 		},
 	}
 	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "list operators in all namespaces")
+	bindClusterFlags(cmd.Flags(), &clusters)
+	bindWatchFlags(cmd.Flags(), &watch, "subscriptions (not their CSVs or catalog sources)")
 	return cmd
 }
+
+func writeOperatorList(w io.Writer, results []clusterResult[[]v1alpha1.Subscription], clusterCol, nsCol bool) {
+	tw := tabwriter.NewWriter(w, 3, 4, 2, ' ', 0)
+	header := operatorListHeader(nsCol)
+	if clusterCol {
+		header = "CLUSTER\t" + header
+	}
+	_, _ = fmt.Fprint(tw, header)
+	for _, r := range results {
+		subs := r.Value
+		sort.SliceStable(subs, func(i, j int) bool {
+			return strings.Compare(subs[i].Spec.Package, subs[j].Spec.Package) < 0
+		})
+		for _, sub := range subs {
+			cluster := ""
+			if clusterCol {
+				cluster = r.Context + "\t"
+			}
+			_, _ = fmt.Fprintf(tw, "%s%s\n", cluster, operatorRow(sub, nsCol))
+		}
+	}
+	_ = tw.Flush()
+}
+
+func operatorListHeader(nsCol bool) string {
+	if nsCol {
+		return "PACKAGE\tNAMESPACE\tSUBSCRIPTION\tINSTALLED CSV\tCURRENT CSV\tSTATUS\tAGE\n"
+	}
+	return "PACKAGE\tSUBSCRIPTION\tINSTALLED CSV\tCURRENT CSV\tSTATUS\tAGE\n"
+}
+
+func operatorRow(sub v1alpha1.Subscription, nsCol bool) string {
+	ns := ""
+	if nsCol {
+		ns = "\t" + sub.Namespace
+	}
+	age := time.Since(sub.CreationTimestamp.Time)
+	return fmt.Sprintf("%s%s\t%s\t%s\t%s\t%s\t%s", sub.Spec.Package, ns, sub.Name, sub.Status.InstalledCSV, sub.Status.CurrentCSV, sub.Status.State, duration.HumanDuration(age))
+}
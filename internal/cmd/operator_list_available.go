@@ -1,10 +1,10 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
-	"sort"
-	"strings"
 	"text/tabwriter"
 	"time"
 
@@ -14,21 +14,46 @@ import (
 	"k8s.io/apimachinery/pkg/util/duration"
 
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
-	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/internal/pkg/operator"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
 func newOperatorListAvailableCmd(cfg *action.Configuration) *cobra.Command {
-	l := internalaction.NewOperatorListAvailable(cfg)
+	var clusters clusterFlags
+	l := action.NewOperatorListAvailable(cfg)
 	cmd := &cobra.Command{
-		Use:   "list-available",
+		Use:   "list-available [operator]",
 		Short: "List operators available to be installed",
-		Args:  cobra.MaximumNArgs(1),
+		Long: `List operators available to be installed.
+
+Use --search to find operators by name, display name, keywords or description.
+When searching, operators are sorted by relevance; otherwise they are sorted by
+name. The --provider, --capability-level and --install-mode filters apply to
+the head of each operator's default channel, or of the channel given with
+--has-channel.
+
+Use --contexts or --all-contexts to query several clusters in parallel. The
+output then gets a CLUSTER column, and clusters that cannot be queried are
+reported after the table.`,
+		Args: cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) == 1 {
 				l.Package = args[0]
 			}
 
+			if clusters.enabled() {
+				results := runOnClusters(cmd.Context(), cfg, clusters, func(ctx context.Context, c *action.Configuration) ([]operator.PackageManifest, error) {
+					cl := action.NewOperatorListAvailable(c)
+					cl.ListAvailableOptions = l.ListAvailableOptions
+					return cl.Run(ctx)
+				})
+				writeAvailableOperators(os.Stdout, results, true)
+				if failed := logClusterErrors(results); failed > 0 {
+					log.Fatalf("%d of %d clusters failed", failed, len(results))
+				}
+				return
+			}
+
 			operators, err := l.Run(cmd.Context())
 			if err != nil {
 				log.Fatal(err)
@@ -42,26 +67,42 @@ func newOperatorListAvailableCmd(cfg *action.Configuration) *cobra.Command {
 				}
 				return
 			}
-
-			sort.SliceStable(operators, func(i, j int) bool {
-				return strings.Compare(operators[i].Name, operators[j].Name) < 0
-			})
-
-			tw := tabwriter.NewWriter(os.Stdout, 3, 4, 2, ' ', 0)
-			_, _ = fmt.Fprintf(tw, "NAME\tCATALOG\tCHANNEL\tLATEST CSV\tAGE\n")
-			for _, op := range operators {
-				age := time.Since(op.CreationTimestamp.Time)
-				for _, ch := range op.Status.Channels {
-					_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", op.Name, op.Status.CatalogSourceDisplayName, ch.Name, ch.CurrentCSV, duration.HumanDuration(age))
-				}
-			}
-			_ = tw.Flush()
+			writeAvailableOperators(os.Stdout, []clusterResult[[]operator.PackageManifest]{{Value: operators}}, false)
 		},
 	}
 	bindOperatorListAvailableFlags(cmd.Flags(), l)
+	bindClusterFlags(cmd.Flags(), &clusters)
 	return cmd
 }
 
-func bindOperatorListAvailableFlags(fs *pflag.FlagSet, l *internalaction.OperatorListAvailable) {
+func bindOperatorListAvailableFlags(fs *pflag.FlagSet, l *action.OperatorListAvailable) {
 	fs.VarP(&l.Catalog, "catalog", "c", "catalog to query (default: search all cluster catalogs)")
+	fs.StringVarP(&l.Search, "search", "s", "", "only list operators matching all of the given search terms")
+	fs.StringVar(&l.Provider, "provider", "", "only list operators whose provider name contains the given value")
+	fs.StringVar(&l.CapabilityLevel, "capability-level", "", "only list operators with the given capability level (e.g. \"Seamless Upgrades\")")
+	fs.StringVar(&l.InstallMode, "install-mode", "", "only list operators supporting the given install mode (e.g. AllNamespaces)")
+	fs.StringVar(&l.Channel, "has-channel", "", "only list operators that have the given channel")
+}
+
+func writeAvailableOperators(w io.Writer, results []clusterResult[[]operator.PackageManifest], clusterCol bool) {
+	tw := tabwriter.NewWriter(w, 3, 4, 2, ' ', 0)
+	header := "NAME\tCATALOG\tCHANNEL\tLATEST CSV\tAGE\n"
+	if clusterCol {
+		header = "CLUSTER\t" + header
+	}
+	_, _ = fmt.Fprint(tw, header)
+	for _, r := range results {
+		cluster := ""
+		if clusterCol {
+			cluster = r.Context + "\t"
+		}
+		// operators are already sorted by name, or by relevance when searching
+		for _, op := range r.Value {
+			age := time.Since(op.CreationTimestamp.Time)
+			for _, ch := range op.Status.Channels {
+				_, _ = fmt.Fprintf(tw, "%s%s\t%s\t%s\t%s\t%s\n", cluster, op.Name, op.Status.CatalogSourceDisplayName, ch.Name, ch.CurrentCSV, duration.HumanDuration(age))
+			}
+		}
+	}
+	_ = tw.Flush()
 }
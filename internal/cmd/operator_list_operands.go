@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,6 +14,7 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
@@ -23,6 +25,7 @@ func newOperatorListOperandsCmd(cfg *action.Configuration) *cobra.Command {
 	l := action.NewOperatorListOperands(cfg)
 	output := ""
 	validOutputs := []string{"json", "yaml"}
+	watch := false
 
 	cmd := &cobra.Command{
 		Use:   "list-operands <operator>",
@@ -38,9 +41,28 @@ To search for operands for an operator in a different namespace, use the
 --namespace flag. By default, the namespace from the current context is used.
 
 Operand kinds are determined from the owned CustomResourceDefinitions listed in
-the operator's ClusterServiceVersion.`,
+the operator's ClusterServiceVersion.
+
+Use --watch to keep printing operands as they are added, change or are
+deleted, like 'kubectl get --watch'. Each row starts with the event type. With
+--output json, each event is printed as a JSON watch event on its own line.
+The operator's CSVs and the cluster's CRDs are watched too, so operand kinds
+added later, for example by an upgrade, are followed as well.`,
 		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
+			if watch {
+				if output != "" && output != "json" {
+					log.Fatalf("invalid value for flag output %q with --watch, expected json", output)
+				}
+				row := func(obj client.Object) string {
+					return operandRow(obj.(*unstructured.Unstructured))
+				}
+				runWatch(cmd, output, operandTableHeader, row, func(ctx context.Context, handler func(action.WatchEvent) error) error {
+					return l.Watch(ctx, args[0], handler)
+				})
+				return
+			}
+
 			writeOutput := func(io.Writer, *unstructured.UnstructuredList) error { panic("writeOutput was not set") } //nolint:staticcheck
 			switch output {
 			case "json":
@@ -69,18 +91,18 @@ the operator's ClusterServiceVersion.`,
 		},
 	}
 	cmd.Flags().StringVarP(&output, "output", "o", output, fmt.Sprintf("Output format. One of: %s", strings.Join(validOutputs, "|")))
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "after listing, watch for changes and print them as they happen")
 	return cmd
 }
 
 func writeTable(w io.Writer, operands *unstructured.UnstructuredList) error {
 	var buf bytes.Buffer
 	tw := tabwriter.NewWriter(&buf, 3, 4, 2, ' ', 0)
-	if _, err := fmt.Fprintf(tw, "APIVERSION\tKIND\tNAMESPACE\tNAME\tAGE\n"); err != nil {
+	if _, err := fmt.Fprint(tw, operandTableHeader); err != nil {
 		return err
 	}
-	for _, o := range operands.Items {
-		age := time.Since(o.GetCreationTimestamp().Time)
-		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", o.GetAPIVersion(), o.GetKind(), o.GetNamespace(), o.GetName(), duration.HumanDuration(age)); err != nil {
+	for i := range operands.Items {
+		if _, err := fmt.Fprintf(tw, "%s\n", operandRow(&operands.Items[i])); err != nil {
 			return err
 		}
 	}
@@ -93,6 +115,13 @@ func writeTable(w io.Writer, operands *unstructured.UnstructuredList) error {
 	return nil
 }
 
+const operandTableHeader = "APIVERSION\tKIND\tNAMESPACE\tNAME\tAGE\n"
+
+func operandRow(o *unstructured.Unstructured) string {
+	age := time.Since(o.GetCreationTimestamp().Time)
+	return fmt.Sprintf("%s\t%s\t%s\t%s\t%s", o.GetAPIVersion(), o.GetKind(), o.GetNamespace(), o.GetName(), duration.HumanDuration(age))
+}
+
 func writeJSON(w io.Writer, operands *unstructured.UnstructuredList) error {
 	out, err := json.Marshal(operands)
 	if err != nil {
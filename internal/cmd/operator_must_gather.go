@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newOperatorMustGatherCmd(cfg *action.Configuration) *cobra.Command {
+	g := internalaction.NewOperatorMustGather(cfg)
+	g.Logf = log.Printf
+	output := ""
+
+	cmd := &cobra.Command{
+		Use:   "must-gather [<operator>]",
+		Short: "Collect diagnostics for OLM-managed operators",
+		Long: `Collect diagnostics for OLM-managed operators.
+
+The must-gather command writes a gzipped tarball with the objects related to
+an operator, for attaching to bug reports:
+
+  - the subscription, its install plans and its cluster service version
+  - the operator groups in the namespace
+  - the operator object and every component it references
+  - the catalog source and its registry pods
+  - the operator's deployments and pods
+  - the logs of the catalog source and operator pods
+  - the events of the collected objects
+
+If no operator is given, every operator in the namespace is collected, along
+with every event in the namespace.
+
+The data of any Secret is redacted.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 1 {
+				g.Package = args[0]
+			}
+			if output == "" {
+				output = fmt.Sprintf("must-gather-%s-%s.tar.gz", cfg.Namespace, time.Now().Format("20060102-150405"))
+			}
+
+			var w io.Writer = os.Stdout
+			if output == "-" {
+				g.Logf = func(string, ...interface{}) {}
+			} else {
+				f, err := os.Create(output)
+				if err != nil {
+					log.Fatalf("create output file: %v", err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			if err := g.Run(cmd.Context(), w); err != nil {
+				if output != "-" {
+					_ = os.Remove(output)
+				}
+				log.Fatalf("gather diagnostics: %v", err)
+			}
+			if output != "-" {
+				log.Printf("diagnostics written to %q", output)
+			}
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", "file to write the tarball to, or - for stdout (default must-gather-<namespace>-<timestamp>.tar.gz)")
+	return cmd
+}
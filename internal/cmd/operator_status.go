@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newOperatorStatusCmd(cfg *action.Configuration) *cobra.Command {
+	var (
+		allNamespaces bool
+		clusters      clusterFlags
+	)
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the health of installed operators",
+		Long: `Show the health of installed operators.
+
+For each subscription, status joins the subscription with its catalog source,
+install plan, cluster service version and the operator's deployments. An
+operator is healthy if its catalog source is reachable, its CSV succeeded and
+all of its deployments are available. A pending upgrade does not make an
+operator unhealthy. The reasons for each unhealthy operator are listed after
+the table.
+
+Use --contexts or --all-contexts to check several clusters in parallel. The
+output then gets a CLUSTER column, and clusters that cannot be checked are
+reported after the table.
+
+The command exits with a non-zero status if any operator is unhealthy, or any
+cluster cannot be checked, so it can be used for CI and cron checks.`,
+		Args: cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			status := func(ctx context.Context, c *action.Configuration) ([]internalaction.OperatorHealth, error) {
+				if allNamespaces {
+					c.Namespace = corev1.NamespaceAll
+				}
+				healths, err := internalaction.NewOperatorStatus(c).Run(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("get operator status: %v", err)
+				}
+				return healths, nil
+			}
+
+			var results []clusterResult[[]internalaction.OperatorHealth]
+			if !clusters.enabled() {
+				healths, err := status(cmd.Context(), cfg)
+				if err != nil {
+					log.Fatal(err)
+				}
+				if len(healths) == 0 {
+					if cfg.Namespace == corev1.NamespaceAll {
+						log.Print("No resources found")
+					} else {
+						log.Printf("No resources found in %s namespace.", cfg.Namespace)
+					}
+					return
+				}
+				results = []clusterResult[[]internalaction.OperatorHealth]{{Value: healths}}
+			} else {
+				results = runOnClusters(cmd.Context(), cfg, clusters, status)
+			}
+
+			total, unhealthy := writeOperatorStatus(os.Stdout, results, clusters.enabled(), allNamespaces)
+			failed := logClusterErrors(results)
+			if unhealthy > 0 {
+				log.Print()
+				for _, r := range results {
+					prefix := ""
+					if clusters.enabled() {
+						prefix = r.Context + ": "
+					}
+					for _, h := range r.Value {
+						for _, p := range h.Problems {
+							log.Printf("%s%s/%s: %s", prefix, h.Subscription.Namespace, h.Subscription.Spec.Package, p)
+						}
+					}
+				}
+			}
+			switch {
+			case unhealthy > 0 && failed > 0:
+				log.Fatalf("%d of %d operators unhealthy; %d of %d clusters failed", unhealthy, total, failed, len(results))
+			case unhealthy > 0:
+				log.Fatalf("%d of %d operators unhealthy", unhealthy, total)
+			case failed > 0:
+				log.Fatalf("%d of %d clusters failed", failed, len(results))
+			}
+		},
+	}
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "show operators in all namespaces")
+	bindClusterFlags(cmd.Flags(), &clusters)
+	return cmd
+}
+
+// writeOperatorStatus writes the health table and returns the number of
+// operators and of unhealthy operators.
+func writeOperatorStatus(w io.Writer, results []clusterResult[[]internalaction.OperatorHealth], clusterCol, nsCol bool) (int, int) {
+	tw := tabwriter.NewWriter(w, 3, 4, 2, ' ', 0)
+	header := "PACKAGE\tCSV\tPHASE\tREASON\tDEPLOYMENTS\tUPGRADE PENDING\tCATALOG REACHABLE\tHEALTHY\n"
+	if nsCol {
+		header = "PACKAGE\tNAMESPACE\tCSV\tPHASE\tREASON\tDEPLOYMENTS\tUPGRADE PENDING\tCATALOG REACHABLE\tHEALTHY\n"
+	}
+	if clusterCol {
+		header = "CLUSTER\t" + header
+	}
+	_, _ = fmt.Fprint(tw, header)
+
+	total, unhealthy := 0, 0
+	for _, r := range results {
+		healths := r.Value
+		sort.SliceStable(healths, func(i, j int) bool {
+			return strings.Compare(healths[i].Subscription.Spec.Package, healths[j].Subscription.Spec.Package) < 0
+		})
+		for _, h := range healths {
+			cluster, ns := "", ""
+			if clusterCol {
+				cluster = r.Context + "\t"
+			}
+			if nsCol {
+				ns = "\t" + h.Subscription.Namespace
+			}
+			csvName, phase, reason := "", "", ""
+			if h.CSV != nil {
+				csvName, phase, reason = h.CSV.Name, string(h.CSV.Status.Phase), string(h.CSV.Status.Reason)
+			}
+			total++
+			if !h.Healthy() {
+				unhealthy++
+			}
+			_, _ = fmt.Fprintf(tw, "%s%s%s\t%s\t%s\t%s\t%d/%d\t%t\t%t\t%t\n", cluster, h.Subscription.Spec.Package, ns, csvName, phase, reason,
+				h.DeploymentsAvailable, h.DeploymentsDesired, h.UpgradePending, h.CatalogReachable, h.Healthy())
+		}
+	}
+	_ = tw.Flush()
+	return total, unhealthy
+}
@@ -7,13 +7,12 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
-	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
 	"github.com/operator-framework/kubectl-operator/internal/pkg/operand"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
 func newOperatorUninstallCmd(cfg *action.Configuration) *cobra.Command {
-	u := internalaction.NewOperatorUninstall(cfg)
+	u := action.NewOperatorUninstall(cfg)
 	u.Logf = log.Printf
 
 	cmd := &cobra.Command{
@@ -82,7 +81,7 @@ individually uninstalled.
 	return cmd
 }
 
-func bindOperatorUninstallFlags(fs *pflag.FlagSet, u *internalaction.OperatorUninstall) {
+func bindOperatorUninstallFlags(fs *pflag.FlagSet, u *action.OperatorUninstall) {
 	fs.BoolVarP(&u.DeleteAll, "delete-all", "X", false, "delete all objects associated with the operator, implies --delete-operator, --operand-strategy=delete, --delete-operator-groups")
 	fs.BoolVar(&u.DeleteOperator, "delete-operator", false, "delete operator object associated with the operator, --operand-strategy=delete")
 	fs.BoolVar(&u.DeleteOperatorGroups, "delete-operator-groups", false, "delete operator groups if no other operators remain")
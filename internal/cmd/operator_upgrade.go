@@ -1,22 +1,49 @@
 package cmd
 
 import (
+	"context"
+
 	"github.com/spf13/cobra"
-	"github.com/spf13/pflag"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
-	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
 func newOperatorUpgradeCmd(cfg *action.Configuration) *cobra.Command {
-	u := internalaction.NewOperatorUpgrade(cfg)
+	var clusters clusterFlags
+	u := action.NewOperatorUpgrade(cfg)
 	cmd := &cobra.Command{
 		Use:   "upgrade <operator>",
 		Short: "Upgrade an operator",
-		Args:  cobra.ExactArgs(1),
+		Long: `Upgrade an operator by approving its pending install plan.
+
+If the operator was installed with a version constraint, install plans for
+versions outside of the constraint are not approved.
+
+Use --contexts or --all-contexts to upgrade the operator on several clusters
+in parallel. A failed upgrade on one cluster does not stop the others; the
+failures are reported at the end.`,
+		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			u.Package = args[0]
+			if clusters.enabled() {
+				results := runOnClusters(cmd.Context(), cfg, clusters, func(ctx context.Context, c *action.Configuration) (*v1alpha1.ClusterServiceVersion, error) {
+					cu := action.NewOperatorUpgrade(c)
+					cu.UpgradeOptions = u.UpgradeOptions
+					return cu.Run(ctx)
+				})
+				for _, r := range results {
+					if r.Err == nil {
+						clusterLogf(r.Context)("operator %q upgraded; installed csv is %q", u.Package, r.Value.Name)
+					}
+				}
+				if failed := logClusterErrors(results); failed > 0 {
+					log.Fatalf("failed to upgrade operator on %d of %d clusters", failed, len(results))
+				}
+				return
+			}
 			csv, err := u.Run(cmd.Context())
 			if err != nil {
 				log.Fatalf("failed to upgrade operator: %v", err)
@@ -24,10 +51,6 @@ func newOperatorUpgradeCmd(cfg *action.Configuration) *cobra.Command {
 			log.Printf("operator %q upgraded; installed csv is %q", u.Package, csv.Name)
 		},
 	}
-	bindOperatorUpgradeFlags(cmd.Flags(), u)
+	bindClusterFlags(cmd.Flags(), &clusters)
 	return cmd
 }
-
-func bindOperatorUpgradeFlags(fs *pflag.FlagSet, u *internalaction.OperatorUpgrade) {
-	fs.StringVarP(&u.Channel, "channel", "c", "", "subscription channel")
-}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newOperatorGroupCmd(cfg *action.Configuration) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "operatorgroup",
+		Aliases: []string{"og"},
+		Short:   "Manage operator groups",
+		Long: `Manage operator groups.
+
+An operator group selects the namespaces watched by the operators installed in
+its namespace. A namespace may only have one operator group.`,
+	}
+	cmd.AddCommand(
+		newOperatorGroupCreateCmd(cfg),
+		newOperatorGroupListCmd(cfg),
+		newOperatorGroupDescribeCmd(cfg),
+		newOperatorGroupSetTargetsCmd(cfg),
+		newOperatorGroupDeleteCmd(cfg),
+		newOperatorGroupCheckCmd(cfg),
+		newOperatorGroupResolveCmd(cfg),
+	)
+	return cmd
+}
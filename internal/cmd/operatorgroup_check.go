@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newOperatorGroupCheckCmd(cfg *action.Configuration) *cobra.Command {
+	var allNamespaces bool
+	c := internalaction.NewOperatorGroupCheck(cfg)
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Find namespaces with no or several operator groups",
+		Long: `Find namespaces with no or several operator groups.
+
+OLM requires exactly one operator group in each namespace with subscriptions.
+Without one, OLM does not install or upgrade the namespace's operators. With
+several, the namespace's CSVs go to phase Failed with reason
+TooManyOperatorGroups.
+
+For each namespace found, the subscriptions and the operator group their CSV
+was configured by are listed. Use 'operatorgroup resolve' to fix the namespace.`,
+		Args: cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			if allNamespaces {
+				cfg.Namespace = corev1.NamespaceAll
+			}
+			broken, err := c.Run(cmd.Context())
+			if err != nil {
+				log.Fatal(err)
+			}
+			if len(broken) == 0 {
+				log.Print("No problems found")
+				return
+			}
+			for i, n := range broken {
+				if i > 0 {
+					fmt.Println()
+				}
+				writeNamespaceOperatorGroups(os.Stdout, n)
+			}
+			log.Fatalf("\nfound %d namespace(s) without exactly one operator group; use 'operatorgroup resolve' to fix them", len(broken))
+		},
+	}
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "check operator groups in all namespaces")
+	return cmd
+}
+
+func writeNamespaceOperatorGroups(w io.Writer, n internalaction.NamespaceOperatorGroups) {
+	_, _ = fmt.Fprintln(w, n.Impact())
+
+	if len(n.OperatorGroups) > 0 {
+		_, _ = fmt.Fprintln(w, "\nOperator Groups:")
+		tw := tabwriter.NewWriter(w, 3, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "  NAME\tTARGETS\tDEPENDENT SUBSCRIPTIONS")
+		for _, og := range n.OperatorGroups {
+			_, _ = fmt.Fprintf(tw, "  %s\t%s\t%s\n", og.Name, formatTargets(og), orNone(strings.Join(n.DependentSubscriptions(og.Name), ",")))
+		}
+		_ = tw.Flush()
+	}
+
+	if len(n.Subscriptions) > 0 {
+		_, _ = fmt.Fprintln(w, "\nSubscriptions:")
+		tw := tabwriter.NewWriter(w, 3, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "  NAME\tPACKAGE\tCSV\tOPERATOR GROUP")
+		for _, s := range n.Subscriptions {
+			_, _ = fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", s.Subscription.Name, s.Subscription.Spec.Package, orNone(s.CSV), orNone(s.OperatorGroup))
+		}
+		_ = tw.Flush()
+	}
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newOperatorGroupCreateCmd(cfg *action.Configuration) *cobra.Command {
+	c := internalaction.NewOperatorGroupCreate(cfg)
+	cmd := &cobra.Command{
+		Use:   "create [<name>]",
+		Short: "Create an operator group",
+		Long: `Create an operator group in the namespace.
+
+The operator group is named after the namespace unless a name is given. It
+targets the namespaces given with --target-namespaces, or the namespaces
+matching --selector. With neither flag, it targets all namespaces.
+
+Before creating the operator group, the install modes of the operators already
+installed in the namespace are checked against the targets, and a --selector
+that matches no namespaces is refused.
+
+The operator group is written with server-side apply, so running the command
+again with different flags updates the existing operator group in place.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 1 {
+				c.Name = args[0]
+			}
+			og, err := c.Run(cmd.Context())
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Printf("operatorgroup %q applied", og.Name)
+		},
+	}
+	bindOperatorGroupTargetFlags(cmd, &c.TargetNamespaces, &c.Selector)
+	cmd.Flags().StringVar(&c.UpgradeStrategy, "upgrade-strategy", "", "upgrade strategy for operators in the namespace (Default|TechPreviewUnsafeFailForward)")
+	cmd.Flags().StringVar(&c.ServiceAccountName, "service-account", "", "service account used to install operators in the namespace")
+	return cmd
+}
+
+func bindOperatorGroupTargetFlags(cmd *cobra.Command, targetNamespaces *[]string, selector *string) {
+	cmd.Flags().StringSliceVarP(targetNamespaces, "target-namespaces", "t", nil, "namespaces the operator group targets")
+	cmd.Flags().StringVarP(selector, "selector", "l", "", "label selector of the namespaces the operator group targets")
+	cmd.MarkFlagsMutuallyExclusive("target-namespaces", "selector")
+}
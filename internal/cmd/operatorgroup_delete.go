@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newOperatorGroupDeleteCmd(cfg *action.Configuration) *cobra.Command {
+	d := internalaction.NewOperatorGroupDelete(cfg)
+	cmd := &cobra.Command{
+		Use:   "delete <operatorgroup>",
+		Short: "Delete an operator group",
+		Long: `Delete an operator group.
+
+The operator group is not deleted while operators are subscribed in the
+namespace, unless --force is set.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			d.Name = args[0]
+			if err := d.Run(cmd.Context()); err != nil {
+				log.Fatal(err)
+			}
+			log.Printf("operatorgroup %q deleted", d.Name)
+		},
+	}
+	cmd.Flags().BoolVar(&d.Force, "force", false, "delete the operator group even if operators are subscribed in the namespace")
+	return cmd
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newOperatorGroupDescribeCmd(cfg *action.Configuration) *cobra.Command {
+	d := internalaction.NewOperatorGroupDescribe(cfg)
+	cmd := &cobra.Command{
+		Use:   "describe <operatorgroup>",
+		Short: "Describe an operator group",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			d.Name = args[0]
+			desc, err := d.Run(cmd.Context())
+			if err != nil {
+				log.Fatal(err)
+			}
+			writeOperatorGroup(os.Stdout, desc)
+		},
+	}
+	return cmd
+}
+
+func writeOperatorGroup(w io.Writer, desc *internalaction.OperatorGroupDescription) {
+	og := desc.OperatorGroup
+	namespaces := make([]string, 0, len(og.Status.Namespaces))
+	for _, ns := range og.Status.Namespaces {
+		if ns == "" {
+			ns = "<all namespaces>"
+		}
+		namespaces = append(namespaces, ns)
+	}
+
+	tw := tabwriter.NewWriter(w, 3, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "Name:\t%s\n", og.Name)
+	_, _ = fmt.Fprintf(tw, "Namespace:\t%s\n", og.Namespace)
+	_, _ = fmt.Fprintf(tw, "Targets:\t%s\n", formatTargets(og))
+	_, _ = fmt.Fprintf(tw, "Target Namespaces:\t%s\n", orNone(strings.Join(namespaces, ", ")))
+	_, _ = fmt.Fprintf(tw, "Upgrade Strategy:\t%s\n", og.UpgradeStrategy())
+	_, _ = fmt.Fprintf(tw, "Service Account:\t%s\n", orNone(og.Spec.ServiceAccountName))
+	if og.Status.LastUpdated != nil {
+		_, _ = fmt.Fprintf(tw, "Last Updated:\t%s\n", og.Status.LastUpdated.Time)
+	}
+	_ = tw.Flush()
+
+	if len(og.Status.Conditions) > 0 {
+		_, _ = fmt.Fprintln(w, "\nConditions:")
+		tw = tabwriter.NewWriter(w, 3, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "  TYPE\tSTATUS\tREASON\tMESSAGE")
+		for _, c := range og.Status.Conditions {
+			_, _ = fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", c.Type, c.Status, c.Reason, c.Message)
+		}
+		_ = tw.Flush()
+	}
+
+	_, _ = fmt.Fprintln(w, "\nOperators:")
+	if len(desc.CSVs) == 0 {
+		_, _ = fmt.Fprintln(w, "  <none>")
+		return
+	}
+	tw = tabwriter.NewWriter(w, 3, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "  CSV\tPHASE\tSUPPORTED INSTALL MODES")
+	for _, csv := range desc.CSVs {
+		modes := sets.New[string]()
+		for _, im := range csv.Spec.InstallModes {
+			if im.Supported {
+				modes.Insert(string(im.Type))
+			}
+		}
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\t%s\n", csv.Name, csv.Status.Phase, strings.Join(sets.List(modes), ","))
+	}
+	_ = tw.Flush()
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newOperatorGroupListCmd(cfg *action.Configuration) *cobra.Command {
+	var allNamespaces bool
+	l := internalaction.NewOperatorGroupList(cfg)
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List operator groups",
+		Args:  cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			if allNamespaces {
+				cfg.Namespace = corev1.NamespaceAll
+			}
+			ogs, err := l.Run(cmd.Context())
+			if err != nil {
+				log.Fatalf("list operator groups: %v", err)
+			}
+
+			if len(ogs) == 0 {
+				if cfg.Namespace == corev1.NamespaceAll {
+					log.Print("No resources found")
+				} else {
+					log.Printf("No resources found in %s namespace.", cfg.Namespace)
+				}
+				return
+			}
+
+			nsCol := ""
+			if allNamespaces {
+				nsCol = "\tNAMESPACE"
+			}
+			tw := tabwriter.NewWriter(os.Stdout, 3, 4, 2, ' ', 0)
+			_, _ = fmt.Fprintf(tw, "NAME%s\tTARGETS\tUPGRADE STRATEGY\tSERVICE ACCOUNT\tAGE\n", nsCol)
+			for _, og := range ogs {
+				ns := ""
+				if allNamespaces {
+					ns = "\t" + og.Namespace
+				}
+				age := time.Since(og.CreationTimestamp.Time)
+				_, _ = fmt.Fprintf(tw, "%s%s\t%s\t%s\t%s\t%s\n", og.Name, ns, formatTargets(og), og.UpgradeStrategy(), orNone(og.Spec.ServiceAccountName), duration.HumanDuration(age))
+			}
+			_ = tw.Flush()
+		},
+	}
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "list operator groups in all namespaces")
+	return cmd
+}
+
+// formatTargets describes the target namespaces of an operator group as
+// configured in its spec.
+func formatTargets(og v1.OperatorGroup) string {
+	switch {
+	case len(og.Spec.TargetNamespaces) > 0:
+		return strings.Join(og.Spec.TargetNamespaces, ",")
+	case og.Spec.Selector != nil:
+		return "selector: " + metav1.FormatLabelSelector(og.Spec.Selector)
+	default:
+		return "<all namespaces>"
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newOperatorGroupResolveCmd(cfg *action.Configuration) *cobra.Command {
+	r := internalaction.NewOperatorGroupResolve(cfg)
+	r.Logf = log.Printf
+	c := internalaction.NewOperatorGroupCheck(cfg)
+	var interactive bool
+	cmd := &cobra.Command{
+		Use:   "resolve",
+		Short: "Leave the namespace with exactly one operator group",
+		Long: `Leave the namespace with exactly one operator group.
+
+If the namespace has subscriptions but no operator group, an operator group is
+created. It targets the namespace itself if the installed operators support
+it, and all namespaces otherwise.
+
+If the namespace has several operator groups, choose the one to keep with
+--keep; the others are deleted. Use --merge to also set the kept operator
+group's targets to the union of the targets of all the operator groups. Use
+--interactive to be shown the operator groups and the subscriptions that
+depend on each, and be asked which to keep.`,
+		Args: cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			if interactive {
+				broken, err := c.Run(cmd.Context())
+				if err != nil {
+					log.Fatal(err)
+				}
+				if len(broken) == 0 {
+					log.Print("No problems found")
+					return
+				}
+				if err := promptResolve(cmd.InOrStdin(), os.Stdout, broken[0], r); err != nil {
+					log.Fatal(err)
+				}
+			}
+			if _, err := r.Run(cmd.Context()); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&r.Keep, "keep", "", "operator group to keep; the others are deleted")
+	cmd.Flags().BoolVar(&r.Merge, "merge", false, "merge the targets of all the operator groups into the kept one")
+	cmd.Flags().BoolVar(&r.Force, "force", false, "resolve even if installed operators do not support the resulting targets")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "prompt for the operator group to keep")
+	cmd.MarkFlagsMutuallyExclusive("interactive", "keep")
+	return cmd
+}
+
+// promptResolve shows the namespace's operator groups and asks which to keep
+// and whether to merge their targets.
+func promptResolve(in io.Reader, out io.Writer, n internalaction.NamespaceOperatorGroups, r *internalaction.OperatorGroupResolve) error {
+	writeNamespaceOperatorGroups(out, n)
+	if len(n.OperatorGroups) < 2 {
+		return nil
+	}
+
+	scanner := bufio.NewScanner(in)
+	ask := func(prompt string) (string, error) {
+		_, _ = fmt.Fprint(out, prompt)
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return "", err
+			}
+			return "", fmt.Errorf("no answer given")
+		}
+		return strings.TrimSpace(scanner.Text()), nil
+	}
+
+	_, _ = fmt.Fprintln(out)
+	for i, og := range n.OperatorGroups {
+		_, _ = fmt.Fprintf(out, "  %d) %s\n", i+1, og.Name)
+	}
+	answer, err := ask("Operator group to keep: ")
+	if err != nil {
+		return err
+	}
+	choice, err := strconv.Atoi(answer)
+	if err != nil || choice < 1 || choice > len(n.OperatorGroups) {
+		return fmt.Errorf("invalid choice %q", answer)
+	}
+	r.Keep = n.OperatorGroups[choice-1].Name
+
+	answer, err = ask("Merge the targets of the other operator groups into it? [y/N]: ")
+	if err != nil {
+		return err
+	}
+	r.Merge = strings.EqualFold(answer, "y") || strings.EqualFold(answer, "yes")
+	return nil
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newOperatorGroupSetTargetsCmd(cfg *action.Configuration) *cobra.Command {
+	s := internalaction.NewOperatorGroupSetTargets(cfg)
+	var allNamespaces bool
+	cmd := &cobra.Command{
+		Use:   "set-targets <operatorgroup>",
+		Short: "Change the target namespaces of an operator group",
+		Long: `Change the target namespaces of an operator group.
+
+Set the targets with --target-namespaces or --selector, or use
+--all-namespaces to target all namespaces.
+
+The change is refused if an operator already installed in the namespace does
+not support the resulting install mode, or if --selector matches no
+namespaces, unless --force is set.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if !allNamespaces && len(s.TargetNamespaces) == 0 && s.Selector == "" {
+				log.Fatal("one of --target-namespaces, --selector or --all-namespaces is required")
+			}
+			s.Name = args[0]
+			og, err := s.Run(cmd.Context())
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Printf("operatorgroup %q targets %s", og.Name, formatTargets(*og))
+		},
+	}
+	bindOperatorGroupTargetFlags(cmd, &s.TargetNamespaces, &s.Selector)
+	cmd.Flags().BoolVar(&allNamespaces, "all-namespaces", false, "target all namespaces")
+	cmd.Flags().BoolVar(&s.Force, "force", false, "change the targets even if installed operators do not support them or the selector matches no namespaces")
+	cmd.MarkFlagsMutuallyExclusive("all-namespaces", "target-namespaces")
+	cmd.MarkFlagsMutuallyExclusive("all-namespaces", "selector")
+	return cmd
+}
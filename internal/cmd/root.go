@@ -43,6 +43,11 @@ operators from the installed catalogs.`,
 
 		cmd.SetContext(ctx)
 
+		// A broken current context must not stop a command that runs
+		// against other contexts.
+		if fansOut(cmd.Flags()) {
+			return nil
+		}
 		return cfg.Load()
 	}
 	cmd.PersistentPostRun = func(command *cobra.Command, _ []string) {
@@ -51,10 +56,15 @@ operators from the installed catalogs.`,
 
 	cmd.AddCommand(
 		newCatalogCmd(&cfg),
+		newInstallPlanCmd(&cfg),
+		newOperatorGroupCmd(&cfg),
 		newOperatorInstallCmd(&cfg),
 		newOperatorUpgradeCmd(&cfg),
 		newOperatorUninstallCmd(&cfg),
 		newOperatorListCmd(&cfg),
+		newOperatorStatusCmd(&cfg),
+		newOperatorDoctorCmd(&cfg),
+		newOperatorMustGatherCmd(&cfg),
 		newOperatorListAvailableCmd(&cfg),
 		newOperatorListOperandsCmd(&cfg),
 		newOperatorDescribeCmd(&cfg),
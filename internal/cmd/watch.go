@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// watchFlags select the watch mode of the list commands.
+type watchFlags struct {
+	watch  bool
+	output string
+}
+
+// bindWatchFlags binds the watch flags of a command that watches kind, which
+// is named in the help as the only resource whose changes are printed.
+func bindWatchFlags(fs *pflag.FlagSet, f *watchFlags, kind string) {
+	fs.BoolVarP(&f.watch, "watch", "w", false, fmt.Sprintf("after listing, watch %s for changes and print them as they happen", kind))
+	fs.StringVarP(&f.output, "output", "o", "", "output format of --watch; json prints one event per line")
+}
+
+// validate returns an error for an output format that is not supported in
+// the selected mode.
+func (f watchFlags) validate() error {
+	switch {
+	case f.output == "":
+		return nil
+	case !f.watch:
+		return fmt.Errorf("--output %s is only supported with --watch", f.output)
+	case f.output != "json":
+		return fmt.Errorf("invalid value for flag output %q, expected json", f.output)
+	}
+	return nil
+}
+
+// runWatch prints the events of watch until it fails or the command is
+// interrupted. Events are printed as table rows with a leading EVENT column,
+// or with output "json" as one JSON watch event per line. The command's
+// --timeout does not apply.
+func runWatch(cmd *cobra.Command, output, header string, row func(client.Object) string, watch func(context.Context, func(action.WatchEvent) error) error) {
+	ctx, stop := signal.NotifyContext(context.WithoutCancel(cmd.Context()), os.Interrupt)
+	defer stop()
+
+	var handler func(action.WatchEvent) error
+	if output == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		handler = func(e action.WatchEvent) error {
+			return enc.Encode(e)
+		}
+	} else {
+		tw := tabwriter.NewWriter(os.Stdout, 3, 4, 2, ' ', 0)
+		_, _ = fmt.Fprint(tw, "EVENT\t"+header)
+		handler = func(e action.WatchEvent) error {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\n", e.Type, row(e.Object))
+			return tw.Flush()
+		}
+	}
+
+	if err := watch(ctx, handler); err != nil && !errors.Is(err, context.Canceled) {
+		log.Fatalf("watch: %v", err)
+	}
+}
@@ -1,13 +1,46 @@
 package action_test
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
 func TestCommand(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Internal action Suite")
 }
+
+// applyFuncs emulates server-side apply, which the fake client does not
+// support, by creating or replacing the applied object.
+var applyFuncs = interceptor.Funcs{
+	Patch: func(ctx context.Context, cl client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+		if patch.Type() != types.ApplyPatchType {
+			return cl.Patch(ctx, obj, patch, opts...)
+		}
+		po := &client.PatchOptions{}
+		po.ApplyOptions(opts)
+		if po.FieldManager != action.FieldManager {
+			return fmt.Errorf("apply with field manager %q, expected %q", po.FieldManager, action.FieldManager)
+		}
+
+		existing := obj.DeepCopyObject().(client.Object)
+		if err := cl.Get(ctx, client.ObjectKeyFromObject(obj), existing); apierrors.IsNotFound(err) {
+			return cl.Create(ctx, obj)
+		} else if err != nil {
+			return err
+		}
+		obj.SetResourceVersion(existing.GetResourceVersion())
+		return cl.Update(ctx, obj)
+	},
+}
@@ -0,0 +1,135 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/pkg/image"
+	"github.com/operator-framework/operator-registry/pkg/image/containerdregistry"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// configsLabel is the index image label that points to the root of the
+// file-based catalog within the image filesystem.
+const configsLabel = "operators.operatorframework.io.index.configs.v1"
+
+// CatalogContent reads the file-based catalog content of a single package
+// from the index image referenced by a CatalogSource.
+type CatalogContent struct {
+	config *action.Configuration
+
+	CatalogSource types.NamespacedName
+	Package       string
+
+	Logf            func(string, ...interface{})
+	RegistryOptions []containerdregistry.RegistryOption
+}
+
+func NewCatalogContent(cfg *action.Configuration) *CatalogContent {
+	return &CatalogContent{
+		config: cfg,
+		Logf:   func(string, ...interface{}) {},
+	}
+}
+
+func (c *CatalogContent) Run(ctx context.Context) (*declcfg.DeclarativeConfig, error) {
+	cs := v1alpha1.CatalogSource{}
+	if err := c.config.Client.Get(ctx, c.CatalogSource, &cs); err != nil {
+		return nil, fmt.Errorf("get catalogsource: %v", err)
+	}
+	if cs.Spec.Image == "" {
+		return nil, fmt.Errorf("catalogsource %q does not reference an index image", c.CatalogSource)
+	}
+
+	reg, err := containerdregistry.NewRegistry(c.RegistryOptions...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := reg.Destroy(); err != nil {
+			c.Logf("registry cleanup: %v", err)
+		}
+	}()
+
+	ref := image.SimpleReference(cs.Spec.Image)
+	if err := reg.Pull(ctx, ref); err != nil {
+		return nil, fmt.Errorf("pull image %q: %v", ref, err)
+	}
+	labels, err := reg.Labels(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("get image labels: %v", err)
+	}
+	configsDir, ok := labels[configsLabel]
+	if !ok {
+		return nil, fmt.Errorf("index image %q does not contain a file-based catalog", ref)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "kubectl-operator-catalog-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := reg.Unpack(ctx, ref, tmpDir); err != nil {
+		return nil, fmt.Errorf("unpack image %q: %v", ref, err)
+	}
+
+	root := os.DirFS(filepath.Join(tmpDir, configsDir))
+	var (
+		mu    sync.Mutex
+		metas []*declcfg.Meta
+	)
+	if err := declcfg.WalkMetasFS(ctx, root, func(_ string, meta *declcfg.Meta, err error) error {
+		if err != nil {
+			return err
+		}
+		if !c.inPackage(meta) {
+			return nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		metas = append(metas, meta)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("read catalog content: %v", err)
+	}
+	return declcfg.LoadSlice(metas)
+}
+
+func (c *CatalogContent) inPackage(meta *declcfg.Meta) bool {
+	if c.Package == "" {
+		return true
+	}
+	if meta.Schema == declcfg.SchemaPackage {
+		return meta.Name == c.Package
+	}
+	return meta.Package == c.Package
+}
+
+// bundleCSV returns the ClusterServiceVersion embedded in the named bundle.
+func bundleCSV(cfg *declcfg.DeclarativeConfig, bundleName string) (*declcfg.Bundle, *v1alpha1.ClusterServiceVersion, error) {
+	for i := range cfg.Bundles {
+		b := &cfg.Bundles[i]
+		if b.Name != bundleName {
+			continue
+		}
+		if b.CsvJSON == "" {
+			return b, nil, fmt.Errorf("bundle %q does not contain a clusterserviceversion", bundleName)
+		}
+		csv := &v1alpha1.ClusterServiceVersion{}
+		if err := json.Unmarshal([]byte(b.CsvJSON), csv); err != nil {
+			return b, nil, fmt.Errorf("decode clusterserviceversion for bundle %q: %v", bundleName, err)
+		}
+		return b, csv, nil
+	}
+	return nil, nil, fmt.Errorf("bundle %q not found in catalog", bundleName)
+}
@@ -1,5 +0,0 @@
-package action
-
-const (
-	csvKind = "ClusterServiceVersion"
-)
@@ -0,0 +1,80 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/cluster"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// InstallPlanApprove approves an install plan by name, so that OLM runs it.
+type InstallPlanApprove struct {
+	config *action.Configuration
+
+	Name string
+}
+
+func NewInstallPlanApprove(cfg *action.Configuration) *InstallPlanApprove {
+	return &InstallPlanApprove{
+		config: cfg,
+	}
+}
+
+func (a *InstallPlanApprove) Run(ctx context.Context) (*v1alpha1.InstallPlan, error) {
+	ip, err := getInstallPlan(ctx, a.config, a.Name)
+	if err != nil {
+		return nil, err
+	}
+	if ip.Spec.Approved {
+		return nil, fmt.Errorf("install plan %q is already approved", ip.Name)
+	}
+	if ip.Status.Phase == v1alpha1.InstallPlanPhaseFailed {
+		return nil, fmt.Errorf("install plan %q has failed and cannot be approved", ip.Name)
+	}
+	subs, err := getInstallPlanSubscriptions(ctx, a.config, ip)
+	if err != nil {
+		return nil, err
+	}
+	for i := range subs {
+		if err := cluster.CheckInstallPlanVersionConstraint(ctx, a.config.Client, &subs[i], ip); err != nil {
+			return nil, err
+		}
+	}
+	if err := cluster.ApproveInstallPlan(ctx, a.config.Client, ip); err != nil {
+		return nil, fmt.Errorf("approve install plan %q: %v", ip.Name, err)
+	}
+	return ip, nil
+}
+
+// getInstallPlanSubscriptions returns the subscriptions that the install plan
+// was created for: those owning it, or referencing it from their status.
+func getInstallPlanSubscriptions(ctx context.Context, cfg *action.Configuration, ip *v1alpha1.InstallPlan) ([]v1alpha1.Subscription, error) {
+	subs := v1alpha1.SubscriptionList{}
+	if err := cfg.Client.List(ctx, &subs, client.InNamespace(ip.Namespace)); err != nil {
+		return nil, fmt.Errorf("list subscriptions: %v", err)
+	}
+	var owners []v1alpha1.Subscription
+	for _, sub := range subs.Items {
+		if isInstallPlanOwner(sub, ip) {
+			owners = append(owners, sub)
+		}
+	}
+	return owners, nil
+}
+
+func isInstallPlanOwner(sub v1alpha1.Subscription, ip *v1alpha1.InstallPlan) bool {
+	if ref := sub.Status.InstallPlanRef; ref != nil && ref.Namespace == ip.Namespace && ref.Name == ip.Name {
+		return true
+	}
+	for _, ref := range ip.GetOwnerReferences() {
+		if ref.Kind == v1alpha1.SubscriptionKind && (ref.UID == sub.UID || ref.Name == sub.Name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+type InstallPlanDescribe struct {
+	config *action.Configuration
+
+	Name string
+}
+
+func NewInstallPlanDescribe(cfg *action.Configuration) *InstallPlanDescribe {
+	return &InstallPlanDescribe{
+		config: cfg,
+	}
+}
+
+func (d *InstallPlanDescribe) Run(ctx context.Context) (*v1alpha1.InstallPlan, error) {
+	return getInstallPlan(ctx, d.config, d.Name)
+}
+
+func getInstallPlan(ctx context.Context, cfg *action.Configuration, name string) (*v1alpha1.InstallPlan, error) {
+	ip := &v1alpha1.InstallPlan{}
+	key := types.NamespacedName{Namespace: cfg.Namespace, Name: name}
+	if err := cfg.Client.Get(ctx, key, ip); err != nil {
+		return nil, fmt.Errorf("get install plan %q: %v", name, err)
+	}
+	return ip, nil
+}
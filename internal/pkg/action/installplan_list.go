@@ -0,0 +1,28 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+type InstallPlanList struct {
+	config *action.Configuration
+}
+
+func NewInstallPlanList(cfg *action.Configuration) *InstallPlanList {
+	return &InstallPlanList{cfg}
+}
+
+func (l *InstallPlanList) Run(ctx context.Context) ([]v1alpha1.InstallPlan, error) {
+	ips := v1alpha1.InstallPlanList{}
+	if err := l.config.Client.List(ctx, &ips, client.InNamespace(l.config.Namespace)); err != nil {
+		return nil, fmt.Errorf("list install plans: %v", err)
+	}
+	return ips.Items, nil
+}
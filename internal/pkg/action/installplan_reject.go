@@ -0,0 +1,40 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/cluster"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// InstallPlanReject discards an install plan that has not been approved by
+// deleting it.
+type InstallPlanReject struct {
+	config *action.Configuration
+
+	Name string
+}
+
+func NewInstallPlanReject(cfg *action.Configuration) *InstallPlanReject {
+	return &InstallPlanReject{
+		config: cfg,
+	}
+}
+
+func (r *InstallPlanReject) Run(ctx context.Context) error {
+	ip, err := getInstallPlan(ctx, r.config, r.Name)
+	if err != nil {
+		return err
+	}
+	if ip.Spec.Approved && ip.Status.Phase != v1alpha1.InstallPlanPhaseComplete && ip.Status.Phase != v1alpha1.InstallPlanPhaseFailed {
+		return fmt.Errorf("install plan %q is already approved and may be running", ip.Name)
+	}
+	ip.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.InstallPlanKind))
+	if err := r.config.Client.Delete(ctx, ip); err != nil {
+		return fmt.Errorf("delete install plan %q: %v", ip.Name, err)
+	}
+	return cluster.WaitForDeletion(ctx, r.config.Client, ip)
+}
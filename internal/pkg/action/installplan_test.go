@@ -0,0 +1,159 @@
+package action_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/internal/pkg/subscription"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("InstallPlan", func() {
+	var (
+		cfg action.Configuration
+		ip  *v1alpha1.InstallPlan
+	)
+
+	BeforeEach(func() {
+		ip = &v1alpha1.InstallPlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "install-abc", Namespace: "etcd-namespace"},
+			Spec: v1alpha1.InstallPlanSpec{
+				ClusterServiceVersionNames: []string{"etcdoperator.v0.9.4"},
+				Approval:                   v1alpha1.ApprovalManual,
+			},
+			Status: v1alpha1.InstallPlanStatus{Phase: v1alpha1.InstallPlanPhaseRequiresApproval},
+		}
+	})
+
+	build := func() {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+		cfg.Scheme = sch
+		cfg.Client = fake.NewClientBuilder().WithObjects(ip).WithScheme(sch).Build()
+		cfg.Namespace = "etcd-namespace"
+	}
+
+	get := func() (*v1alpha1.InstallPlan, error) {
+		check := &v1alpha1.InstallPlan{}
+		err := cfg.Client.Get(context.TODO(), types.NamespacedName{Namespace: "etcd-namespace", Name: "install-abc"}, check)
+		return check, err
+	}
+
+	It("should list install plans", func() {
+		build()
+		ips, err := internalaction.NewInstallPlanList(&cfg).Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(ips).To(HaveLen(1))
+		Expect(ips[0].Name).To(Equal("install-abc"))
+	})
+
+	It("should approve an install plan by name", func() {
+		build()
+		a := internalaction.NewInstallPlanApprove(&cfg)
+		a.Name = "install-abc"
+		_, err := a.Run(context.TODO())
+		Expect(err).To(BeNil())
+
+		check, err := get()
+		Expect(err).To(BeNil())
+		Expect(check.Spec.Approved).To(BeTrue())
+	})
+
+	Context("created for a subscription with a version constraint", func() {
+		var sub *v1alpha1.Subscription
+
+		BeforeEach(func() {
+			sub = &v1alpha1.Subscription{
+				ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "etcd-namespace"},
+				Spec:       &v1alpha1.SubscriptionSpec{Package: "etcd", Channel: "stable"},
+				Status: v1alpha1.SubscriptionStatus{
+					InstallPlanRef: &corev1.ObjectReference{Namespace: "etcd-namespace", Name: "install-abc"},
+				},
+			}
+			subscription.VersionConstraint("<0.9.4")(sub)
+		})
+
+		buildWithSubscription := func() {
+			build()
+			pm := &operatorsv1.PackageManifest{
+				ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "etcd-namespace"},
+				Status: operatorsv1.PackageManifestStatus{
+					Channels: []operatorsv1.PackageChannel{{
+						Name:    "stable",
+						Entries: []operatorsv1.ChannelEntry{{Name: "etcdoperator.v0.9.4", Version: "0.9.4"}},
+					}},
+				},
+			}
+			Expect(cfg.Client.Create(context.TODO(), sub)).To(Succeed())
+			Expect(cfg.Client.Create(context.TODO(), pm)).To(Succeed())
+		}
+
+		It("should refuse an install plan outside the constraint", func() {
+			buildWithSubscription()
+			a := internalaction.NewInstallPlanApprove(&cfg)
+			a.Name = "install-abc"
+			_, err := a.Run(context.TODO())
+			Expect(err).To(MatchError(ContainSubstring(`refusing to approve install plan "install-abc" for csv "etcdoperator.v0.9.4"`)))
+
+			check, err := get()
+			Expect(err).To(BeNil())
+			Expect(check.Spec.Approved).To(BeFalse())
+		})
+
+		It("should approve an install plan inside the constraint", func() {
+			subscription.VersionConstraint("<1.0.0")(sub)
+			buildWithSubscription()
+			a := internalaction.NewInstallPlanApprove(&cfg)
+			a.Name = "install-abc"
+			_, err := a.Run(context.TODO())
+			Expect(err).To(BeNil())
+
+			check, err := get()
+			Expect(err).To(BeNil())
+			Expect(check.Spec.Approved).To(BeTrue())
+		})
+	})
+
+	It("should not approve an install plan twice", func() {
+		ip.Spec.Approved = true
+		build()
+		a := internalaction.NewInstallPlanApprove(&cfg)
+		a.Name = "install-abc"
+		_, err := a.Run(context.TODO())
+		Expect(err).To(MatchError(`install plan "install-abc" is already approved`))
+	})
+
+	It("should reject an install plan by deleting it", func() {
+		build()
+		r := internalaction.NewInstallPlanReject(&cfg)
+		r.Name = "install-abc"
+		Expect(r.Run(context.TODO())).To(Succeed())
+
+		_, err := get()
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should not reject a running install plan", func() {
+		ip.Spec.Approved = true
+		ip.Status.Phase = v1alpha1.InstallPlanPhaseInstalling
+		build()
+		r := internalaction.NewInstallPlanReject(&cfg)
+		r.Name = "install-abc"
+		Expect(r.Run(context.TODO())).To(MatchError(`install plan "install-abc" is already approved and may be running`))
+
+		_, err := get()
+		Expect(err).To(BeNil())
+	})
+})
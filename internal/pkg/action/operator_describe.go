@@ -0,0 +1,197 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/pkg/image/containerdregistry"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/operator"
+	"github.com/operator-framework/kubectl-operator/internal/pkg/subscription"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+type OperatorDescribe struct {
+	config *action.Configuration
+
+	Package        string
+	Channel        string
+	Catalog        action.NamespacedName
+	WithBundleData bool
+	WithGraph      bool
+
+	Logf            func(string, ...interface{})
+	RegistryOptions []containerdregistry.RegistryOption
+}
+
+func NewOperatorDescribe(cfg *action.Configuration) *OperatorDescribe {
+	return &OperatorDescribe{
+		config: cfg,
+		Logf:   func(string, ...interface{}) {},
+	}
+}
+
+// OperatorDescription holds the details of a package channel head. CSVDesc
+// starts out as the description served by packageserver. When bundle data is
+// requested, fields packageserver left empty are filled in from the bundle
+// CSV and the permission lists are populated.
+type OperatorDescription struct {
+	PackageManifest operator.PackageManifest
+	Channel         operator.PackageChannel
+	CSVDesc         operatorsv1.CSVDescription
+
+	ClusterPermissions []v1alpha1.StrategyDeploymentPermissions
+	Permissions        []v1alpha1.StrategyDeploymentPermissions
+
+	// HasBundleData is true if the bundle was read from the catalog.
+	HasBundleData bool
+
+	// Graphs holds the upgrade graph of every channel of the package, and
+	// InstalledCSV the CSV installed by the package's subscription in the
+	// namespace, if any. They are only set when a graph is requested.
+	Graphs       []operator.ChannelGraph
+	InstalledCSV string
+}
+
+func (d *OperatorDescribe) Run(ctx context.Context) (*OperatorDescription, error) {
+	l := action.NewOperatorListAvailable(d.config)
+	l.Catalog = d.Catalog
+	l.Package = d.Package
+	pms, err := l.Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(pms) == 0 {
+		return nil, &action.ErrPackageNotFound{PackageName: d.Package}
+	}
+
+	// we only expect one item because describe always searches
+	// for a specific operator by name
+	pm := pms[0]
+	pc, err := pm.GetChannel(d.Channel)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := &OperatorDescription{
+		PackageManifest: pm,
+		Channel:         *pc,
+		CSVDesc:         pc.CurrentCSVDesc,
+	}
+	var fbc *declcfg.DeclarativeConfig
+	if d.WithBundleData {
+		c := NewCatalogContent(d.config)
+		c.CatalogSource = types.NamespacedName{
+			Namespace: pm.Status.CatalogSourceNamespace,
+			Name:      pm.Status.CatalogSource,
+		}
+		c.Package = pm.Name
+		c.Logf = d.Logf
+		c.RegistryOptions = d.RegistryOptions
+		fbc, err = c.Run(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("read catalog bundle data: %v", err)
+		}
+		bundle, csv, err := bundleCSV(fbc, pc.CurrentCSV)
+		if err != nil {
+			return nil, err
+		}
+		desc.mergeBundleCSV(csv)
+		if len(desc.CSVDesc.RelatedImages) == 0 {
+			for _, ri := range bundle.RelatedImages {
+				desc.CSVDesc.RelatedImages = append(desc.CSVDesc.RelatedImages, ri.Image)
+			}
+		}
+		desc.HasBundleData = true
+	}
+
+	if d.WithGraph {
+		if fbc != nil {
+			desc.Graphs, err = operator.GraphsFromDeclarativeConfig(fbc, pm.Name)
+			if err != nil {
+				return nil, fmt.Errorf("build upgrade graph: %v", err)
+			}
+		} else {
+			desc.Graphs = operator.GraphsFromPackageManifest(pm)
+		}
+		desc.InstalledCSV, err = d.installedCSV(ctx, pm.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return desc, nil
+}
+
+// installedCSV returns the CSV installed by the subscription for the package
+// in the configured namespace, or an empty string if there is none.
+func (d *OperatorDescribe) installedCSV(ctx context.Context, packageName string) (string, error) {
+	subs := v1alpha1.SubscriptionList{}
+	if err := d.config.Client.List(ctx, &subs, client.InNamespace(d.config.Namespace)); err != nil {
+		return "", fmt.Errorf("list subscriptions: %v", err)
+	}
+	for _, s := range subs.Items {
+		s := s
+		if packageName == s.Spec.Package {
+			return subscription.CSVName(&s), nil
+		}
+	}
+	return "", nil
+}
+
+// mergeBundleCSV fills fields that packageserver did not serve from the
+// bundle CSV. Values served by packageserver always take precedence.
+func (d *OperatorDescription) mergeBundleCSV(csv *v1alpha1.ClusterServiceVersion) {
+	cd := &d.CSVDesc
+	annotations := make(map[string]string, len(cd.Annotations))
+	for k, v := range cd.Annotations {
+		annotations[k] = v
+	}
+	cd.Annotations = annotations
+	for k, v := range csv.GetAnnotations() {
+		if _, ok := cd.Annotations[k]; !ok {
+			cd.Annotations[k] = v
+		}
+	}
+	if len(cd.Keywords) == 0 {
+		cd.Keywords = csv.Spec.Keywords
+	}
+	if len(cd.Links) == 0 {
+		for _, l := range csv.Spec.Links {
+			cd.Links = append(cd.Links, operatorsv1.AppLink{Name: l.Name, URL: l.URL})
+		}
+	}
+	if cd.Maturity == "" {
+		cd.Maturity = csv.Spec.Maturity
+	}
+	if cd.MinKubeVersion == "" {
+		cd.MinKubeVersion = csv.Spec.MinKubeVersion
+	}
+	if len(cd.CustomResourceDefinitions.Owned) == 0 {
+		cd.CustomResourceDefinitions.Owned = csv.Spec.CustomResourceDefinitions.Owned
+	}
+	if len(cd.CustomResourceDefinitions.Required) == 0 {
+		cd.CustomResourceDefinitions.Required = csv.Spec.CustomResourceDefinitions.Required
+	}
+	if len(cd.APIServiceDefinitions.Owned) == 0 {
+		cd.APIServiceDefinitions.Owned = csv.Spec.APIServiceDefinitions.Owned
+	}
+	if len(cd.APIServiceDefinitions.Required) == 0 {
+		cd.APIServiceDefinitions.Required = csv.Spec.APIServiceDefinitions.Required
+	}
+	if len(cd.NativeAPIs) == 0 {
+		cd.NativeAPIs = csv.Spec.NativeAPIs
+	}
+	if len(cd.RelatedImages) == 0 {
+		for _, ri := range csv.Spec.RelatedImages {
+			cd.RelatedImages = append(cd.RelatedImages, ri.Image)
+		}
+	}
+	d.ClusterPermissions = csv.Spec.InstallStrategy.StrategySpec.ClusterPermissions
+	d.Permissions = csv.Spec.InstallStrategy.StrategySpec.Permissions
+}
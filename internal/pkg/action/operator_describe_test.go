@@ -0,0 +1,142 @@
+package action_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/internal/pkg/operator"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("OperatorDescribe", func() {
+	var (
+		cfg action.Configuration
+		pm  *operatorsv1.PackageManifest
+	)
+
+	BeforeEach(func() {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+
+		pm = &operatorsv1.PackageManifest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "etcd",
+				Namespace: "etcd-namespace",
+			},
+			Status: operatorsv1.PackageManifestStatus{
+				CatalogSource:          "operatorhubio",
+				CatalogSourceNamespace: "olm",
+				DefaultChannel:         "singlenamespace-alpha",
+				Channels: []operatorsv1.PackageChannel{
+					{
+						Name:       "singlenamespace-alpha",
+						CurrentCSV: "etcdoperator.v0.9.4",
+						CurrentCSVDesc: operatorsv1.CSVDescription{
+							DisplayName:    "etcd",
+							Annotations:    map[string]string{"capabilities": "Full Lifecycle"},
+							Keywords:       []string{"etcd", "key value"},
+							Maturity:       "alpha",
+							MinKubeVersion: "1.11.0",
+							CustomResourceDefinitions: v1alpha1.CustomResourceDefinitions{
+								Owned: []v1alpha1.CRDDescription{{
+									Name:    "etcdclusters.etcd.database.coreos.com",
+									Version: "v1beta2",
+									Kind:    "EtcdCluster",
+								}},
+							},
+							RelatedImages: []string{"quay.io/coreos/etcd-operator@sha256:abc"},
+						},
+						Entries: []operatorsv1.ChannelEntry{
+							{Name: "etcdoperator.v0.9.4", Version: "0.9.4"},
+							{Name: "etcdoperator.v0.9.2", Version: "0.9.2"},
+						},
+					},
+					{
+						Name:       "clusterwide-alpha",
+						CurrentCSV: "etcdoperator.v0.9.4-clusterwide",
+					},
+				},
+			},
+		}
+
+		sub := &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "etcd",
+				Namespace: "etcd-namespace",
+			},
+			Spec: &v1alpha1.SubscriptionSpec{
+				Package: "etcd",
+			},
+			Status: v1alpha1.SubscriptionStatus{
+				InstalledCSV: "etcdoperator.v0.9.2",
+			},
+		}
+
+		cfg.Scheme = sch
+		cfg.Client = fake.NewClientBuilder().WithObjects(pm, sub).WithScheme(sch).Build()
+		cfg.Namespace = "etcd-namespace"
+	})
+
+	It("should describe the default channel head from packageserver", func() {
+		d := internalaction.NewOperatorDescribe(&cfg)
+		d.Package = "etcd"
+		desc, err := d.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(desc.HasBundleData).To(BeFalse())
+		Expect(desc.Channel.Name).To(Equal("singlenamespace-alpha"))
+		Expect(desc.CSVDesc.Annotations).To(HaveKeyWithValue("capabilities", "Full Lifecycle"))
+		Expect(desc.CSVDesc.Keywords).To(ConsistOf("etcd", "key value"))
+		Expect(desc.CSVDesc.MinKubeVersion).To(Equal("1.11.0"))
+		Expect(desc.CSVDesc.CustomResourceDefinitions.Owned).To(HaveLen(1))
+		Expect(desc.CSVDesc.RelatedImages).To(ConsistOf("quay.io/coreos/etcd-operator@sha256:abc"))
+	})
+
+	It("should describe the requested channel", func() {
+		d := internalaction.NewOperatorDescribe(&cfg)
+		d.Package = "etcd"
+		d.Channel = "clusterwide-alpha"
+		desc, err := d.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(desc.Channel.CurrentCSV).To(Equal("etcdoperator.v0.9.4-clusterwide"))
+	})
+
+	It("should fail when the channel does not exist", func() {
+		d := internalaction.NewOperatorDescribe(&cfg)
+		d.Package = "etcd"
+		d.Channel = "stable"
+		_, err := d.Run(context.TODO())
+		Expect(err).To(MatchError(operator.ErrChannelNotFound{PackageName: "etcd", ChannelName: "stable"}))
+	})
+
+	It("should build channel graphs from packageserver entries", func() {
+		d := internalaction.NewOperatorDescribe(&cfg)
+		d.Package = "etcd"
+		d.WithGraph = true
+		desc, err := d.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(desc.InstalledCSV).To(Equal("etcdoperator.v0.9.2"))
+		Expect(desc.Graphs).To(HaveLen(2))
+
+		g := desc.Graphs[0]
+		Expect(g.Channel).To(Equal("singlenamespace-alpha"))
+		Expect(g.Default).To(BeTrue())
+		Expect(g.HasEdges).To(BeFalse())
+		Expect(g.Head).To(Equal("etcdoperator.v0.9.4"))
+		Expect(g.Entries).To(HaveLen(2))
+
+		// channels without entries fall back to the channel head
+		g = desc.Graphs[1]
+		Expect(g.Default).To(BeFalse())
+		Expect(g.Entries).To(HaveLen(1))
+		Expect(g.Entries[0].Name).To(Equal("etcdoperator.v0.9.4-clusterwide"))
+	})
+})
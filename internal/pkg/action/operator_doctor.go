@@ -0,0 +1,476 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/operator"
+	"github.com/operator-framework/kubectl-operator/internal/pkg/subscription"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// Severity is how serious a diagnostic finding is.
+type Severity string
+
+const (
+	SeverityError   Severity = "Error"
+	SeverityWarning Severity = "Warning"
+	SeverityInfo    Severity = "Info"
+)
+
+// Finding is the result of a single diagnostic check, with a plain-language
+// cause and a suggested fix.
+type Finding struct {
+	Severity Severity
+	Check    string
+	Cause    string
+	Fix      string
+}
+
+// OperatorDoctor diagnoses why the install or upgrade of a package is stuck.
+type OperatorDoctor struct {
+	config *action.Configuration
+
+	Package string
+}
+
+func NewOperatorDoctor(cfg *action.Configuration) *OperatorDoctor {
+	return &OperatorDoctor{
+		config: cfg,
+	}
+}
+
+func (d *OperatorDoctor) Run(ctx context.Context) ([]Finding, error) {
+	subs := v1alpha1.SubscriptionList{}
+	if err := d.config.Client.List(ctx, &subs, client.InNamespace(d.config.Namespace)); err != nil {
+		return nil, fmt.Errorf("list subscriptions: %v", err)
+	}
+	var sub *v1alpha1.Subscription
+	for _, s := range subs.Items {
+		s := s
+		if d.Package == s.Spec.Package {
+			sub = &s
+			break
+		}
+	}
+	if sub == nil {
+		return nil, &action.ErrPackageNotFound{PackageName: d.Package}
+	}
+
+	var findings []Finding
+	add := func(f ...Finding) {
+		findings = append(findings, f...)
+	}
+
+	add(d.checkSubscriptionConditions(sub)...)
+
+	f, err := d.checkCatalogSource(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+	add(f...)
+
+	f, err = d.checkInstallPlan(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+	add(f...)
+
+	csv, f, err := d.checkCSV(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+	add(f...)
+
+	f, err = d.checkOperatorGroups(ctx, csv)
+	if err != nil {
+		return nil, err
+	}
+	add(f...)
+
+	if csv != nil {
+		f, err = d.checkPods(ctx, csv)
+		if err != nil {
+			return nil, err
+		}
+		add(f...)
+	}
+
+	if len(findings) == 0 {
+		add(Finding{
+			Severity: SeverityInfo,
+			Check:    "summary",
+			Cause:    fmt.Sprintf("no problems found for package %q", d.Package),
+		})
+	}
+	return findings, nil
+}
+
+func (d *OperatorDoctor) checkSubscriptionConditions(sub *v1alpha1.Subscription) []Finding {
+	var findings []Finding
+	for _, c := range sub.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		msg := c.Message
+		if msg == "" {
+			msg = c.Reason
+		}
+		switch c.Type {
+		case v1alpha1.SubscriptionResolutionFailed:
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Check:    "subscription",
+				Cause:    fmt.Sprintf("OLM could not resolve a set of operators that satisfies the subscription: %s", msg),
+				Fix:      "check that the package, channel and starting CSV exist in the catalog and that the operator's dependencies are available; conflicting subscriptions in the namespace can also cause this",
+			})
+		case v1alpha1.SubscriptionCatalogSourcesUnhealthy:
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Check:    "subscription",
+				Cause:    fmt.Sprintf("one or more catalog sources used for resolution are unhealthy: %s", msg),
+				Fix:      "check the catalog source pods with 'kubectl get pods -n <catalog namespace>' and remove or fix broken catalogs",
+			})
+		case v1alpha1.SubscriptionInstallPlanPending:
+			findings = append(findings, Finding{
+				Severity: SeverityWarning,
+				Check:    "subscription",
+				Cause:    fmt.Sprintf("the install plan is pending: %s", msg),
+				Fix:      "if the reason is RequiresApproval, approve the install plan with 'kubectl operator upgrade " + sub.Spec.Package + "'",
+			})
+		case v1alpha1.SubscriptionInstallPlanFailed:
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Check:    "subscription",
+				Cause:    fmt.Sprintf("the install plan failed: %s", msg),
+				Fix:      "inspect the install plan steps for the failing resource, fix it, then delete the install plan so OLM creates a new one",
+			})
+		case v1alpha1.SubscriptionInstallPlanMissing:
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Check:    "subscription",
+				Cause:    fmt.Sprintf("the install plan referenced by the subscription is missing: %s", msg),
+				Fix:      "delete and recreate the subscription so OLM creates a new install plan",
+			})
+		case v1alpha1.SubscriptionBundleUnpackFailed:
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Check:    "subscription",
+				Cause:    fmt.Sprintf("the operator bundle could not be unpacked: %s", msg),
+				Fix:      "check that the bundle image can be pulled from the cluster and look at the unpack job in the catalog namespace",
+			})
+		}
+	}
+	return findings
+}
+
+func (d *OperatorDoctor) checkCatalogSource(ctx context.Context, sub *v1alpha1.Subscription) ([]Finding, error) {
+	key := types.NamespacedName{
+		Namespace: sub.Spec.CatalogSourceNamespace,
+		Name:      sub.Spec.CatalogSource,
+	}
+	cs := &v1alpha1.CatalogSource{}
+	if err := d.config.Client.Get(ctx, key, cs); err != nil {
+		if apierrors.IsNotFound(err) {
+			return []Finding{{
+				Severity: SeverityError,
+				Check:    "catalogsource",
+				Cause:    fmt.Sprintf("catalog source %q referenced by the subscription does not exist", key),
+				Fix:      "add the catalog with 'kubectl operator catalog add' or point the subscription at an existing catalog",
+			}}, nil
+		}
+		return nil, fmt.Errorf("get catalogsource: %v", err)
+	}
+
+	state := ""
+	if cs.Status.GRPCConnectionState != nil {
+		state = cs.Status.GRPCConnectionState.LastObservedState
+	}
+	if state != catalogSourceReady {
+		return []Finding{{
+			Severity: SeverityError,
+			Check:    "catalogsource",
+			Cause:    fmt.Sprintf("catalog source %q is not reachable (connection state %q)", key, state),
+			Fix:      fmt.Sprintf("check the catalog source pod with 'kubectl get pods -n %s -l olm.catalogSource=%s' and that its image can be pulled", key.Namespace, key.Name),
+		}}, nil
+	}
+	return nil, nil
+}
+
+func (d *OperatorDoctor) checkInstallPlan(ctx context.Context, sub *v1alpha1.Subscription) ([]Finding, error) {
+	if sub.Status.InstallPlanRef == nil {
+		if subscription.CSVName(sub) == "" {
+			return []Finding{{
+				Severity: SeverityWarning,
+				Check:    "installplan",
+				Cause:    "the subscription does not reference an install plan yet",
+				Fix:      "wait for OLM to resolve the subscription; if this persists, check the subscription conditions and the catalog-operator logs in the olm namespace",
+			}}, nil
+		}
+		return nil, nil
+	}
+
+	key := types.NamespacedName{
+		Namespace: sub.Status.InstallPlanRef.Namespace,
+		Name:      sub.Status.InstallPlanRef.Name,
+	}
+	ip := &v1alpha1.InstallPlan{}
+	if err := d.config.Client.Get(ctx, key, ip); err != nil {
+		if apierrors.IsNotFound(err) {
+			return []Finding{{
+				Severity: SeverityError,
+				Check:    "installplan",
+				Cause:    fmt.Sprintf("install plan %q referenced by the subscription does not exist", key.Name),
+				Fix:      "delete and recreate the subscription so OLM creates a new install plan",
+			}}, nil
+		}
+		return nil, fmt.Errorf("get install plan: %v", err)
+	}
+
+	switch ip.Status.Phase {
+	case v1alpha1.InstallPlanPhaseRequiresApproval:
+		return []Finding{{
+			Severity: SeverityWarning,
+			Check:    "installplan",
+			Cause:    fmt.Sprintf("install plan %q for %s requires manual approval", ip.Name, strings.Join(ip.Spec.ClusterServiceVersionNames, ", ")),
+			Fix:      "approve it with 'kubectl operator upgrade " + sub.Spec.Package + "'",
+		}}, nil
+	case v1alpha1.InstallPlanPhaseFailed:
+		msg := ""
+		for _, c := range ip.Status.Conditions {
+			if c.Message != "" {
+				msg = c.Message
+			}
+		}
+		return []Finding{{
+			Severity: SeverityError,
+			Check:    "installplan",
+			Cause:    fmt.Sprintf("install plan %q failed: %s", ip.Name, msg),
+			Fix:      "fix the failing resource, then delete the install plan so OLM creates a new one",
+		}}, nil
+	case v1alpha1.InstallPlanPhasePlanning, v1alpha1.InstallPlanPhaseInstalling:
+		return []Finding{{
+			Severity: SeverityInfo,
+			Check:    "installplan",
+			Cause:    fmt.Sprintf("install plan %q is in phase %q", ip.Name, ip.Status.Phase),
+			Fix:      "wait for the install plan to complete",
+		}}, nil
+	}
+	return nil, nil
+}
+
+func (d *OperatorDoctor) checkCSV(ctx context.Context, sub *v1alpha1.Subscription) (*v1alpha1.ClusterServiceVersion, []Finding, error) {
+	name := subscription.CSVName(sub)
+	if name == "" {
+		return nil, nil, nil
+	}
+	key := types.NamespacedName{
+		Namespace: sub.Namespace,
+		Name:      name,
+	}
+	csv := &v1alpha1.ClusterServiceVersion{}
+	if err := d.config.Client.Get(ctx, key, csv); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, []Finding{{
+				Severity: SeverityWarning,
+				Check:    "csv",
+				Cause:    fmt.Sprintf("csv %q has not been created yet", name),
+				Fix:      "wait for the install plan to complete",
+			}}, nil
+		}
+		return nil, nil, fmt.Errorf("get clusterserviceversion: %v", err)
+	}
+
+	var findings []Finding
+	switch csv.Status.Phase {
+	case v1alpha1.CSVPhaseSucceeded:
+	case v1alpha1.CSVPhaseFailed:
+		findings = append(findings, Finding{
+			Severity: SeverityError,
+			Check:    "csv",
+			Cause:    fmt.Sprintf("csv %q failed with reason %q: %s", name, csv.Status.Reason, csv.Status.Message),
+			Fix:      csvReasonFix(csv.Status.Reason),
+		})
+	default:
+		findings = append(findings, Finding{
+			Severity: SeverityWarning,
+			Check:    "csv",
+			Cause:    fmt.Sprintf("csv %q is in phase %q with reason %q: %s", name, csv.Status.Phase, csv.Status.Reason, csv.Status.Message),
+			Fix:      csvReasonFix(csv.Status.Reason),
+		})
+	}
+
+	for _, r := range csv.Status.RequirementStatus {
+		if r.Status == v1alpha1.RequirementStatusReasonPresent {
+			continue
+		}
+		findings = append(findings, Finding{
+			Severity: SeverityError,
+			Check:    "csv requirements",
+			Cause:    fmt.Sprintf("requirement %s %q is %s: %s", r.Kind, r.Name, r.Status, r.Message),
+			Fix:      "make sure the required CRDs, API services and service accounts exist and that OLM has the permissions to create them",
+		})
+	}
+	return csv, findings, nil
+}
+
+// csvReasonFix suggests a fix for a CSV condition reason.
+func csvReasonFix(reason v1alpha1.ConditionReason) string {
+	switch reason {
+	case v1alpha1.CSVReasonTooManyOperatorGroups:
+		return "keep one operator group with 'kubectl operator operatorgroup resolve'"
+	case v1alpha1.CSVReasonNoOperatorGroup:
+		return "create an operator group with 'kubectl operator operatorgroup resolve' or 'kubectl operator operatorgroup create'"
+	case v1alpha1.CSVReasonUnsupportedOperatorGroup, v1alpha1.CSVReasonInvalidInstallModes, v1alpha1.CSVReasonNoTargetNamespaces:
+		return "change the operator group's target namespaces to match an install mode the operator supports"
+	case v1alpha1.CSVReasonRequirementsNotMet, v1alpha1.CSVReasonRequirementsUnknown:
+		return "see the csv requirement findings below"
+	case v1alpha1.CSVReasonComponentUnhealthy, v1alpha1.CSVReasonInstallCheckFailed, v1alpha1.CSVReasonWaiting:
+		return "check the operator deployment and pod findings below"
+	case v1alpha1.CSVReasonOwnerConflict, v1alpha1.CSVReasonInterOperatorGroupOwnerConflict:
+		return "another operator already owns one of this operator's APIs; uninstall the conflicting operator"
+	case v1alpha1.CSVReasonOperatorConditionNotUpgradeable:
+		return "the running operator reports it is not upgradeable; check its OperatorCondition and wait for it to become upgradeable"
+	}
+	return "inspect the csv with 'kubectl describe csv' for more details"
+}
+
+func (d *OperatorDoctor) checkOperatorGroups(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) ([]Finding, error) {
+	ogs := v1.OperatorGroupList{}
+	if err := d.config.Client.List(ctx, &ogs, client.InNamespace(d.config.Namespace)); err != nil {
+		return nil, fmt.Errorf("list operator groups: %v", err)
+	}
+	switch len(ogs.Items) {
+	case 0:
+		return []Finding{{
+			Severity: SeverityError,
+			Check:    "operatorgroup",
+			Cause:    fmt.Sprintf("namespace %q has no operator group, so OLM will not install operators in it", d.config.Namespace),
+			Fix:      "create an operator group with 'kubectl operator operatorgroup resolve' or 'kubectl operator operatorgroup create'",
+		}}, nil
+	case 1:
+	default:
+		names := make([]string, 0, len(ogs.Items))
+		for _, og := range ogs.Items {
+			names = append(names, og.Name)
+		}
+		return []Finding{{
+			Severity: SeverityError,
+			Check:    "operatorgroup",
+			Cause:    fmt.Sprintf("namespace %q has %d operator groups (%s); CSVs in it fail with TooManyOperatorGroups", d.config.Namespace, len(ogs.Items), strings.Join(names, ", ")),
+			Fix:      "keep one operator group with 'kubectl operator operatorgroup resolve --keep <name>', or choose it with --interactive",
+		}}, nil
+	}
+
+	if csv == nil {
+		return nil, nil
+	}
+	operatorInstallModes := sets.New[string]()
+	for _, im := range csv.Spec.InstallModes {
+		if im.Supported {
+			operatorInstallModes.Insert(string(im.Type))
+		}
+	}
+	og := ogs.Items[0]
+	if err := operator.ValidateOperatorGroup(og, operatorInstallModes, operator.PossibleInstallModes(og.Namespace, og.Status.Namespaces)); err != nil {
+		return []Finding{{
+			Severity: SeverityError,
+			Check:    "operatorgroup",
+			Cause:    fmt.Sprintf("operator group %q is not compatible with the operator: %v", og.Name, err),
+			Fix:      "change the operator group's target namespaces to match an install mode the operator supports, or install the operator in another namespace",
+		}}, nil
+	}
+	return nil, nil
+}
+
+func (d *OperatorDoctor) checkPods(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) ([]Finding, error) {
+	var findings []Finding
+	for _, spec := range csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs {
+		dep := &appsv1.Deployment{}
+		key := types.NamespacedName{Namespace: csv.Namespace, Name: spec.Name}
+		if err := d.config.Client.Get(ctx, key, dep); err != nil {
+			if apierrors.IsNotFound(err) {
+				findings = append(findings, Finding{
+					Severity: SeverityError,
+					Check:    "deployment",
+					Cause:    fmt.Sprintf("deployment %q has not been created", spec.Name),
+					Fix:      "check the csv phase and the olm-operator logs in the olm namespace",
+				})
+				continue
+			}
+			return nil, fmt.Errorf("get deployment: %v", err)
+		}
+		if dep.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(dep.Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("parse selector of deployment %q: %v", dep.Name, err)
+		}
+		pods := corev1.PodList{}
+		if err := d.config.Client.List(ctx, &pods, client.InNamespace(dep.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, fmt.Errorf("list pods: %v", err)
+		}
+		if len(pods.Items) == 0 {
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Check:    "pods",
+				Cause:    fmt.Sprintf("deployment %q has no pods", dep.Name),
+				Fix:      fmt.Sprintf("check the deployment's events with 'kubectl describe deployment -n %s %s' for quota or admission errors", dep.Namespace, dep.Name),
+			})
+		}
+		for _, pod := range pods.Items {
+			findings = append(findings, podFindings(pod)...)
+		}
+	}
+	return findings, nil
+}
+
+func podFindings(pod corev1.Pod) []Finding {
+	var findings []Finding
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
+			fix := fmt.Sprintf("check 'kubectl describe pod -n %s %s'", pod.Namespace, pod.Name)
+			switch cs.State.Waiting.Reason {
+			case "ImagePullBackOff", "ErrImagePull":
+				fix = "make sure the image exists and the cluster can pull it; mirrored clusters need the operator's related images"
+			case "CrashLoopBackOff":
+				fix = fmt.Sprintf("check the container logs with 'kubectl logs -n %s %s -c %s --previous'", pod.Namespace, pod.Name, cs.Name)
+			}
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Check:    "pods",
+				Cause:    fmt.Sprintf("container %q of pod %q is waiting: %s %s", cs.Name, pod.Name, cs.State.Waiting.Reason, cs.State.Waiting.Message),
+				Fix:      fix,
+			})
+		} else if !cs.Ready {
+			findings = append(findings, Finding{
+				Severity: SeverityWarning,
+				Check:    "pods",
+				Cause:    fmt.Sprintf("container %q of pod %q is not ready", cs.Name, pod.Name),
+				Fix:      fmt.Sprintf("check the readiness probe and logs with 'kubectl logs -n %s %s -c %s'", pod.Namespace, pod.Name, cs.Name),
+			})
+		}
+	}
+	if pod.Status.Phase == corev1.PodPending && len(pod.Status.ContainerStatuses) == 0 {
+		findings = append(findings, Finding{
+			Severity: SeverityWarning,
+			Check:    "pods",
+			Cause:    fmt.Sprintf("pod %q is pending", pod.Name),
+			Fix:      fmt.Sprintf("check scheduling events with 'kubectl describe pod -n %s %s'", pod.Namespace, pod.Name),
+		})
+	}
+	return findings
+}
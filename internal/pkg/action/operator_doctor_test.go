@@ -0,0 +1,163 @@
+package action_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("OperatorDoctor", func() {
+	var (
+		cfg  action.Configuration
+		objs []client.Object
+		sub  *v1alpha1.Subscription
+		csv  *v1alpha1.ClusterServiceVersion
+		og   *v1.OperatorGroup
+		pod  *corev1.Pod
+	)
+
+	BeforeEach(func() {
+		cs := &v1alpha1.CatalogSource{
+			ObjectMeta: metav1.ObjectMeta{Name: "operatorhubio", Namespace: "olm"},
+			Status: v1alpha1.CatalogSourceStatus{
+				GRPCConnectionState: &v1alpha1.GRPCConnectionState{LastObservedState: "READY"},
+			},
+		}
+		sub = &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "etcd-namespace"},
+			Spec: &v1alpha1.SubscriptionSpec{
+				Package:                "etcd",
+				CatalogSource:          "operatorhubio",
+				CatalogSourceNamespace: "olm",
+			},
+			Status: v1alpha1.SubscriptionStatus{InstalledCSV: "etcdoperator.v0.9.4"},
+		}
+		csv = &v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcdoperator.v0.9.4", Namespace: "etcd-namespace"},
+			Spec: v1alpha1.ClusterServiceVersionSpec{
+				InstallModes: []v1alpha1.InstallMode{
+					{Type: v1alpha1.InstallModeTypeOwnNamespace, Supported: true},
+					{Type: v1alpha1.InstallModeTypeAllNamespaces, Supported: false},
+				},
+				InstallStrategy: v1alpha1.NamedInstallStrategy{
+					StrategySpec: v1alpha1.StrategyDetailsDeployment{
+						DeploymentSpecs: []v1alpha1.StrategyDeploymentSpec{{Name: "etcd-operator"}},
+					},
+				},
+			},
+			Status: v1alpha1.ClusterServiceVersionStatus{Phase: v1alpha1.CSVPhaseSucceeded},
+		}
+		og = &v1.OperatorGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd-namespace", Namespace: "etcd-namespace"},
+			Status:     v1.OperatorGroupStatus{Namespaces: []string{"etcd-namespace"}},
+		}
+		dep := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd-operator", Namespace: "etcd-namespace"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"name": "etcd-operator"}},
+			},
+		}
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "etcd-operator-abc",
+				Namespace: "etcd-namespace",
+				Labels:    map[string]string{"name": "etcd-operator"},
+			},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "etcd-operator", Ready: true}},
+			},
+		}
+		objs = []client.Object{cs, sub, csv, og, dep, pod}
+	})
+
+	run := func() []internalaction.Finding {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+		cfg.Scheme = sch
+		cfg.Client = fake.NewClientBuilder().WithObjects(objs...).WithScheme(sch).Build()
+		cfg.Namespace = "etcd-namespace"
+
+		d := internalaction.NewOperatorDoctor(&cfg)
+		d.Package = "etcd"
+		findings, err := d.Run(context.TODO())
+		Expect(err).To(BeNil())
+		return findings
+	}
+
+	causes := func(findings []internalaction.Finding, severity internalaction.Severity) []string {
+		out := []string{}
+		for _, f := range findings {
+			if f.Severity == severity {
+				out = append(out, f.Cause)
+			}
+		}
+		return out
+	}
+
+	It("should find no problems with a working operator", func() {
+		findings := run()
+		Expect(causes(findings, internalaction.SeverityError)).To(BeEmpty())
+		Expect(causes(findings, internalaction.SeverityWarning)).To(BeEmpty())
+	})
+
+	It("should diagnose a failed resolution", func() {
+		sub.Status.Conditions = []v1alpha1.SubscriptionCondition{{
+			Type:    v1alpha1.SubscriptionResolutionFailed,
+			Status:  corev1.ConditionTrue,
+			Message: "constraints not satisfiable",
+		}}
+		Expect(causes(run(), internalaction.SeverityError)).To(ContainElement(ContainSubstring("constraints not satisfiable")))
+	})
+
+	It("should diagnose an incompatible operator group", func() {
+		og.Status.Namespaces = []string{""}
+		Expect(causes(run(), internalaction.SeverityError)).To(ContainElement(ContainSubstring(`operator group "etcd-namespace" is not compatible`)))
+	})
+
+	It("should diagnose too many operator groups", func() {
+		objs = append(objs, &v1.OperatorGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "etcd-namespace"},
+		})
+		findings := run()
+		Expect(causes(findings, internalaction.SeverityError)).To(ContainElement(ContainSubstring("TooManyOperatorGroups")))
+		Expect(findings).To(ContainElement(HaveField("Fix", ContainSubstring("kubectl operator operatorgroup resolve --keep"))))
+	})
+
+	It("should point to operatorgroup resolve for a namespace without an operator group", func() {
+		objs = []client.Object{sub, csv}
+		Expect(run()).To(ContainElement(HaveField("Fix", ContainSubstring("kubectl operator operatorgroup resolve"))))
+	})
+
+	It("should diagnose unmet csv requirements and crashing pods", func() {
+		csv.Status.Phase = v1alpha1.CSVPhasePending
+		csv.Status.Reason = v1alpha1.CSVReasonRequirementsNotMet
+		csv.Status.RequirementStatus = []v1alpha1.RequirementStatus{{
+			Kind:   "CustomResourceDefinition",
+			Name:   "etcdclusters.etcd.database.coreos.com",
+			Status: v1alpha1.RequirementStatusReasonNotPresent,
+		}}
+		pod.Status.ContainerStatuses[0].Ready = false
+		pod.Status.ContainerStatuses[0].State.Waiting = &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}
+
+		findings := run()
+		Expect(causes(findings, internalaction.SeverityWarning)).To(ContainElement(ContainSubstring(`is in phase "Pending"`)))
+		Expect(causes(findings, internalaction.SeverityError)).To(ConsistOf(
+			ContainSubstring(`requirement CustomResourceDefinition "etcdclusters.etcd.database.coreos.com" is NotPresent`),
+			ContainSubstring("CrashLoopBackOff"),
+		))
+	})
+})
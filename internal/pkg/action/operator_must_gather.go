@@ -0,0 +1,436 @@
+package action
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/subscription"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+const (
+	// catalogSourcePodLabel is the label OLM sets on the registry pods of a
+	// catalog source.
+	catalogSourcePodLabel = "olm.catalogSource"
+
+	// redacted replaces the values of secret data in the gathered objects.
+	redacted = "REDACTED"
+)
+
+// OperatorMustGather collects the OLM objects, pod logs and events related to
+// one operator, or to every operator in the namespace, into a gzipped tarball
+// that can be attached to bug reports. Secret data is redacted.
+type OperatorMustGather struct {
+	config *action.Configuration
+
+	// Package limits the collection to the operator installed from this
+	// package. If empty, every subscription in the namespace is collected.
+	Package string
+
+	// Clientset is used to read pod logs. If nil, it is built from the
+	// configuration's REST config, and logs are skipped if that is unset.
+	Clientset kubernetes.Interface
+
+	Logf func(string, ...interface{})
+}
+
+func NewOperatorMustGather(cfg *action.Configuration) *OperatorMustGather {
+	return &OperatorMustGather{
+		config: cfg,
+		Logf:   func(string, ...interface{}) {},
+	}
+}
+
+func (g *OperatorMustGather) Run(ctx context.Context, w io.Writer) error {
+	if g.Clientset == nil && g.config.RESTConfig != nil {
+		cs, err := kubernetes.NewForConfig(g.config.RESTConfig)
+		if err != nil {
+			return fmt.Errorf("create clientset: %v", err)
+		}
+		g.Clientset = cs
+	}
+
+	subs := v1alpha1.SubscriptionList{}
+	if err := g.config.Client.List(ctx, &subs, client.InNamespace(g.config.Namespace)); err != nil {
+		return fmt.Errorf("list subscriptions: %v", err)
+	}
+	var selected []v1alpha1.Subscription
+	for _, sub := range subs.Items {
+		if g.Package == "" || g.Package == sub.Spec.Package {
+			selected = append(selected, sub)
+		}
+	}
+	if g.Package != "" && len(selected) == 0 {
+		return &action.ErrPackageNotFound{PackageName: g.Package}
+	}
+
+	gz := gzip.NewWriter(w)
+	gw := &gatherWriter{
+		tw:         tar.NewWriter(gz),
+		scheme:     g.config.Scheme,
+		now:        time.Now(),
+		paths:      sets.New[string](),
+		refs:       sets.New[string](),
+		namespaces: sets.New[string](g.config.Namespace),
+	}
+
+	for _, sub := range selected {
+		sub := sub
+		if err := g.gatherSubscription(ctx, gw, &sub); err != nil {
+			return fmt.Errorf("gather subscription %q: %v", sub.Name, err)
+		}
+	}
+	if err := g.gatherOperatorGroups(ctx, gw); err != nil {
+		return err
+	}
+	if err := g.gatherEvents(ctx, gw); err != nil {
+		return err
+	}
+
+	if err := gw.tw.Close(); err != nil {
+		return fmt.Errorf("write tarball: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("write tarball: %v", err)
+	}
+	return nil
+}
+
+func (g *OperatorMustGather) gatherSubscription(ctx context.Context, gw *gatherWriter, sub *v1alpha1.Subscription) error {
+	g.Logf("gathering operator %q", sub.Spec.Package)
+	if err := gw.addObject(sub); err != nil {
+		return err
+	}
+	if err := g.gatherCatalogSource(ctx, gw, sub); err != nil {
+		return err
+	}
+	if err := g.gatherInstallPlans(ctx, gw, sub); err != nil {
+		return err
+	}
+	if err := g.gatherCSV(ctx, gw, sub); err != nil {
+		return err
+	}
+	return g.gatherOperator(ctx, gw, sub)
+}
+
+func (g *OperatorMustGather) gatherCatalogSource(ctx context.Context, gw *gatherWriter, sub *v1alpha1.Subscription) error {
+	cs := &v1alpha1.CatalogSource{}
+	key := types.NamespacedName{Namespace: sub.Spec.CatalogSourceNamespace, Name: sub.Spec.CatalogSource}
+	if err := g.config.Client.Get(ctx, key, cs); err != nil {
+		if apierrors.IsNotFound(err) {
+			g.Logf("catalogsource %q not found", key)
+			return nil
+		}
+		return fmt.Errorf("get catalogsource: %v", err)
+	}
+	if err := gw.addObject(cs); err != nil {
+		return err
+	}
+
+	pods := corev1.PodList{}
+	if err := g.config.Client.List(ctx, &pods, client.InNamespace(cs.Namespace), client.MatchingLabels{catalogSourcePodLabel: cs.Name}); err != nil {
+		return fmt.Errorf("list catalogsource pods: %v", err)
+	}
+	return g.gatherPods(ctx, gw, pods.Items)
+}
+
+// gatherInstallPlans collects the install plans owned by the subscription, and
+// the one it currently references.
+func (g *OperatorMustGather) gatherInstallPlans(ctx context.Context, gw *gatherWriter, sub *v1alpha1.Subscription) error {
+	ips := v1alpha1.InstallPlanList{}
+	if err := g.config.Client.List(ctx, &ips, client.InNamespace(sub.Namespace)); err != nil {
+		return fmt.Errorf("list install plans: %v", err)
+	}
+	for _, ip := range ips.Items {
+		ip := ip
+		if !ownedBy(&ip, sub) && (sub.Status.InstallPlanRef == nil || sub.Status.InstallPlanRef.Name != ip.Name) {
+			continue
+		}
+		if err := gw.addObject(&ip); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *OperatorMustGather) gatherCSV(ctx context.Context, gw *gatherWriter, sub *v1alpha1.Subscription) error {
+	name := subscription.CSVName(sub)
+	if name == "" {
+		return nil
+	}
+	csv := &v1alpha1.ClusterServiceVersion{}
+	if err := g.config.Client.Get(ctx, types.NamespacedName{Namespace: sub.Namespace, Name: name}, csv); err != nil {
+		if apierrors.IsNotFound(err) {
+			g.Logf("csv %q not found", name)
+			return nil
+		}
+		return fmt.Errorf("get clusterserviceversion: %v", err)
+	}
+	if err := gw.addObject(csv); err != nil {
+		return err
+	}
+
+	for _, spec := range csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs {
+		dep := &appsv1.Deployment{}
+		if err := g.config.Client.Get(ctx, types.NamespacedName{Namespace: csv.Namespace, Name: spec.Name}, dep); err != nil {
+			if apierrors.IsNotFound(err) {
+				g.Logf("deployment %q not found", spec.Name)
+				continue
+			}
+			return fmt.Errorf("get deployment: %v", err)
+		}
+		if err := gw.addObject(dep); err != nil {
+			return err
+		}
+		if spec.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(spec.Spec.Selector)
+		if err != nil {
+			return fmt.Errorf("parse selector of deployment %q: %v", spec.Name, err)
+		}
+		pods := corev1.PodList{}
+		if err := g.config.Client.List(ctx, &pods, client.InNamespace(csv.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return fmt.Errorf("list pods: %v", err)
+		}
+		if err := g.gatherPods(ctx, gw, pods.Items); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gatherOperator collects the operator object and every component it
+// references. Components that cannot be read are logged and skipped.
+func (g *OperatorMustGather) gatherOperator(ctx context.Context, gw *gatherWriter, sub *v1alpha1.Subscription) error {
+	op := &v1.Operator{}
+	key := types.NamespacedName{Name: fmt.Sprintf("%s.%s", sub.Spec.Package, sub.Namespace)}
+	if err := g.config.Client.Get(ctx, key, op); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("get operator: %v", err)
+	}
+	if err := gw.addObject(op); err != nil {
+		return err
+	}
+	if op.Status.Components == nil {
+		return nil
+	}
+	for _, ref := range op.Status.Components.Refs {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(ref.GroupVersionKind())
+		if err := g.config.Client.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, obj); err != nil {
+			g.Logf("skipping %s %q: %v", strings.ToLower(ref.Kind), ref.Name, err)
+			continue
+		}
+		if err := gw.addObject(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *OperatorMustGather) gatherOperatorGroups(ctx context.Context, gw *gatherWriter) error {
+	ogs := v1.OperatorGroupList{}
+	if err := g.config.Client.List(ctx, &ogs, client.InNamespace(g.config.Namespace)); err != nil {
+		return fmt.Errorf("list operatorgroups: %v", err)
+	}
+	for _, og := range ogs.Items {
+		og := og
+		if err := gw.addObject(&og); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *OperatorMustGather) gatherPods(ctx context.Context, gw *gatherWriter, pods []corev1.Pod) error {
+	for _, pod := range pods {
+		pod := pod
+		if err := gw.addObject(&pod); err != nil {
+			return err
+		}
+		if g.Clientset == nil {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if err := g.gatherLogs(ctx, gw, &pod, status.Name, false); err != nil {
+				return err
+			}
+			if status.RestartCount > 0 {
+				if err := g.gatherLogs(ctx, gw, &pod, status.Name, true); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (g *OperatorMustGather) gatherLogs(ctx context.Context, gw *gatherWriter, pod *corev1.Pod, container string, previous bool) error {
+	opts := &corev1.PodLogOptions{Container: container, Previous: previous}
+	logs, err := g.Clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).DoRaw(ctx)
+	if err != nil {
+		g.Logf("skipping logs of pod %q container %q: %v", pod.Name, container, err)
+		return nil
+	}
+	name := container + ".log"
+	if previous {
+		name = container + ".previous.log"
+	}
+	return gw.addFile(path.Join("namespaces", pod.Namespace, "pods", pod.Name, name), logs)
+}
+
+// gatherEvents collects the events of the gathered objects. When gathering the
+// whole namespace, every event in the namespace is collected.
+func (g *OperatorMustGather) gatherEvents(ctx context.Context, gw *gatherWriter) error {
+	for _, ns := range sets.List(gw.namespaces) {
+		wholeNamespace := g.Package == "" && ns == g.config.Namespace
+		events := corev1.EventList{}
+		if err := g.config.Client.List(ctx, &events, client.InNamespace(ns)); err != nil {
+			return fmt.Errorf("list events: %v", err)
+		}
+		for _, ev := range events.Items {
+			ev := ev
+			involved := ev.InvolvedObject
+			if !wholeNamespace && !gw.refs.Has(refKey(involved.Kind, involved.Namespace, involved.Name)) {
+				continue
+			}
+			if err := gw.addObject(&ev); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func ownedBy(obj metav1.Object, owner metav1.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == owner.GetUID() && ref.Name == owner.GetName() {
+			return true
+		}
+	}
+	return false
+}
+
+func refKey(kind, namespace, name string) string {
+	return path.Join(kind, namespace, name)
+}
+
+// gatherWriter writes gathered objects and files to a tarball, skipping
+// objects that were already written.
+type gatherWriter struct {
+	tw     *tar.Writer
+	scheme *runtime.Scheme
+	now    time.Time
+
+	paths      sets.Set[string]
+	refs       sets.Set[string]
+	namespaces sets.Set[string]
+}
+
+func (gw *gatherWriter) addObject(obj client.Object) error {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		gvk, err := apiutil.GVKForObject(obj, gw.scheme)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("marshal %s %q: %v", strings.ToLower(gvk.Kind), obj.GetName(), err)
+		}
+		u = &unstructured.Unstructured{}
+		if err := json.Unmarshal(data, &u.Object); err != nil {
+			return fmt.Errorf("unmarshal %s %q: %v", strings.ToLower(gvk.Kind), obj.GetName(), err)
+		}
+		u.SetGroupVersionKind(gvk)
+	}
+	u = u.DeepCopy()
+	u.SetManagedFields(nil)
+	redactSecret(u)
+
+	kind := strings.ToLower(u.GetKind())
+	p := path.Join("cluster", kind, u.GetName()+".yaml")
+	if u.GetNamespace() != "" {
+		p = path.Join("namespaces", u.GetNamespace(), kind, u.GetName()+".yaml")
+		gw.namespaces.Insert(u.GetNamespace())
+	}
+	if gw.paths.Has(p) {
+		return nil
+	}
+	gw.refs.Insert(refKey(u.GetKind(), u.GetNamespace(), u.GetName()))
+
+	data, err := yaml.Marshal(u.Object)
+	if err != nil {
+		return fmt.Errorf("marshal %s %q: %v", kind, u.GetName(), err)
+	}
+	return gw.addFile(p, data)
+}
+
+func (gw *gatherWriter) addFile(name string, data []byte) error {
+	if gw.paths.Has(name) {
+		return nil
+	}
+	gw.paths.Insert(name)
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: gw.now,
+	}
+	if err := gw.tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("write %q: %v", name, err)
+	}
+	if _, err := gw.tw.Write(data); err != nil {
+		return fmt.Errorf("write %q: %v", name, err)
+	}
+	return nil
+}
+
+// redactSecret replaces the values of a secret's data, and drops the
+// last-applied-configuration annotation, which may contain the same data.
+func redactSecret(u *unstructured.Unstructured) {
+	gvk := u.GroupVersionKind()
+	if gvk.Group != "" || gvk.Kind != "Secret" {
+		return
+	}
+	for _, field := range []string{"data", "stringData"} {
+		data, ok := u.Object[field].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for k := range data {
+			data[k] = redacted
+		}
+	}
+	annotations := u.GetAnnotations()
+	if _, ok := annotations[corev1.LastAppliedConfigAnnotation]; ok {
+		delete(annotations, corev1.LastAppliedConfigAnnotation)
+		u.SetAnnotations(annotations)
+	}
+}
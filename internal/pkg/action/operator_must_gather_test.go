@@ -0,0 +1,180 @@
+package action_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("OperatorMustGather", func() {
+	var (
+		cfg  action.Configuration
+		objs []client.Object
+	)
+
+	BeforeEach(func() {
+		sub := &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "etcd-namespace", UID: types.UID("sub-uid")},
+			Spec: &v1alpha1.SubscriptionSpec{
+				Package:                "etcd",
+				CatalogSource:          "operatorhubio",
+				CatalogSourceNamespace: "olm",
+			},
+			Status: v1alpha1.SubscriptionStatus{InstalledCSV: "etcdoperator.v0.9.4"},
+		}
+		objs = []client.Object{
+			sub,
+			&v1alpha1.Subscription{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "etcd-namespace"},
+				Spec:       &v1alpha1.SubscriptionSpec{Package: "other"},
+			},
+			&v1alpha1.CatalogSource{
+				ObjectMeta: metav1.ObjectMeta{Name: "operatorhubio", Namespace: "olm"},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "operatorhubio-xyz",
+					Namespace: "olm",
+					Labels:    map[string]string{"olm.catalogSource": "operatorhubio"},
+				},
+				Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{Name: "registry-server"}}},
+			},
+			&v1alpha1.InstallPlan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "install-abc",
+					Namespace:       "etcd-namespace",
+					OwnerReferences: []metav1.OwnerReference{{Name: "etcd", UID: types.UID("sub-uid")}},
+				},
+			},
+			&v1alpha1.InstallPlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "install-other", Namespace: "etcd-namespace"},
+			},
+			&v1alpha1.ClusterServiceVersion{
+				ObjectMeta: metav1.ObjectMeta{Name: "etcdoperator.v0.9.4", Namespace: "etcd-namespace"},
+			},
+			&v1.OperatorGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "etcd-namespace", Namespace: "etcd-namespace"},
+			},
+			&v1.Operator{
+				ObjectMeta: metav1.ObjectMeta{Name: "etcd.etcd-namespace"},
+				Status: v1.OperatorStatus{
+					Components: &v1.Components{
+						Refs: []v1.RichReference{{
+							ObjectReference: &corev1.ObjectReference{
+								APIVersion: "v1",
+								Kind:       "Secret",
+								Name:       "etcd-webhook-cert",
+								Namespace:  "etcd-namespace",
+							},
+						}},
+					},
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "etcd-webhook-cert", Namespace: "etcd-namespace"},
+				Data:       map[string][]byte{"tls.key": []byte("very-secret")},
+			},
+			&corev1.Event{
+				ObjectMeta:     metav1.ObjectMeta{Name: "etcd.1", Namespace: "etcd-namespace"},
+				InvolvedObject: corev1.ObjectReference{Kind: "InstallPlan", Name: "install-abc", Namespace: "etcd-namespace"},
+			},
+			&corev1.Event{
+				ObjectMeta:     metav1.ObjectMeta{Name: "other.1", Namespace: "etcd-namespace"},
+				InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "unrelated", Namespace: "etcd-namespace"},
+			},
+		}
+	})
+
+	run := func(pkg string) map[string]string {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+		cfg.Scheme = sch
+		cfg.Client = fake.NewClientBuilder().WithObjects(objs...).WithScheme(sch).Build()
+		cfg.Namespace = "etcd-namespace"
+
+		g := internalaction.NewOperatorMustGather(&cfg)
+		g.Package = pkg
+		g.Clientset = kubefake.NewSimpleClientset()
+
+		var buf bytes.Buffer
+		Expect(g.Run(context.TODO(), &buf)).To(Succeed())
+
+		gz, err := gzip.NewReader(&buf)
+		Expect(err).To(BeNil())
+		tr := tar.NewReader(gz)
+		files := map[string]string{}
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			Expect(err).To(BeNil())
+			data, err := io.ReadAll(tr)
+			Expect(err).To(BeNil())
+			files[hdr.Name] = string(data)
+		}
+		return files
+	}
+
+	It("should gather the objects related to an operator", func() {
+		files := run("etcd")
+		Expect(files).To(HaveKey("namespaces/etcd-namespace/subscription/etcd.yaml"))
+		Expect(files).To(HaveKey("namespaces/etcd-namespace/installplan/install-abc.yaml"))
+		Expect(files).To(HaveKey("namespaces/etcd-namespace/clusterserviceversion/etcdoperator.v0.9.4.yaml"))
+		Expect(files).To(HaveKey("namespaces/etcd-namespace/operatorgroup/etcd-namespace.yaml"))
+		Expect(files).To(HaveKey("namespaces/olm/catalogsource/operatorhubio.yaml"))
+		Expect(files).To(HaveKey("namespaces/olm/pod/operatorhubio-xyz.yaml"))
+		Expect(files).To(HaveKey("namespaces/olm/pods/operatorhubio-xyz/registry-server.log"))
+		Expect(files).To(HaveKey("cluster/operator/etcd.etcd-namespace.yaml"))
+		Expect(files).To(HaveKey("namespaces/etcd-namespace/event/etcd.1.yaml"))
+
+		Expect(files).NotTo(HaveKey("namespaces/etcd-namespace/subscription/other.yaml"))
+		Expect(files).NotTo(HaveKey("namespaces/etcd-namespace/installplan/install-other.yaml"))
+		Expect(files).NotTo(HaveKey("namespaces/etcd-namespace/event/other.1.yaml"))
+	})
+
+	It("should redact secrets", func() {
+		files := run("etcd")
+		Expect(files).To(HaveKey("namespaces/etcd-namespace/secret/etcd-webhook-cert.yaml"))
+		secret := files["namespaces/etcd-namespace/secret/etcd-webhook-cert.yaml"]
+		Expect(secret).To(ContainSubstring("tls.key: REDACTED"))
+		Expect(secret).NotTo(ContainSubstring("very-secret"))
+	})
+
+	It("should gather every operator and event in the namespace", func() {
+		files := run("")
+		Expect(files).To(HaveKey("namespaces/etcd-namespace/subscription/etcd.yaml"))
+		Expect(files).To(HaveKey("namespaces/etcd-namespace/subscription/other.yaml"))
+		Expect(files).To(HaveKey("namespaces/etcd-namespace/event/other.1.yaml"))
+	})
+
+	It("should fail for an unknown operator", func() {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+		cfg.Scheme = sch
+		cfg.Client = fake.NewClientBuilder().WithScheme(sch).Build()
+		cfg.Namespace = "etcd-namespace"
+
+		g := internalaction.NewOperatorMustGather(&cfg)
+		g.Package = "missing"
+		Expect(g.Run(context.TODO(), io.Discard)).To(MatchError(ContainSubstring(`package "missing" not found`)))
+	})
+})
@@ -0,0 +1,192 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-registry/pkg/image/containerdregistry"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/cluster"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// OperatorPermissions reads the permissions an operator requests from its
+// bundle CSV, so they can be reviewed before the operator is installed with a
+// scoped operator group service account.
+type OperatorPermissions struct {
+	config *action.Configuration
+
+	Package string
+	Catalog action.NamespacedName
+	Channel string
+	Version string
+
+	Logf            func(string, ...interface{})
+	RegistryOptions []containerdregistry.RegistryOption
+}
+
+func NewOperatorPermissions(cfg *action.Configuration) *OperatorPermissions {
+	return &OperatorPermissions{
+		config: cfg,
+		Logf:   func(string, ...interface{}) {},
+	}
+}
+
+// InstallPermissions are the permissions requested by the CSV that would be
+// installed for a package.
+type InstallPermissions struct {
+	CSVName            string
+	Permissions        []v1alpha1.StrategyDeploymentPermissions
+	ClusterPermissions []v1alpha1.StrategyDeploymentPermissions
+	OwnedCRDs          []v1alpha1.CRDDescription
+}
+
+func (p *OperatorPermissions) Run(ctx context.Context) (*InstallPermissions, error) {
+	pm, err := cluster.GetPackageManifest(ctx, p.config.Client, p.config.Namespace, p.Package, p.Catalog.NamespacedName)
+	if err != nil {
+		return nil, fmt.Errorf("get package manifest: %v", err)
+	}
+	pc, err := pm.GetChannel(p.Channel)
+	if err != nil {
+		return nil, fmt.Errorf("get package channel: %v", err)
+	}
+	csvName := pc.CurrentCSV
+	if p.Version != "" {
+		if csvName, err = pc.StartingCSV(p.Version); err != nil {
+			return nil, fmt.Errorf("get starting CSV: %v", err)
+		}
+	}
+
+	// packageserver does not serve the CSV install strategy, so the
+	// permissions are read from the bundle in the catalog.
+	c := NewCatalogContent(p.config)
+	c.CatalogSource = types.NamespacedName{
+		Namespace: pm.Status.CatalogSourceNamespace,
+		Name:      pm.Status.CatalogSource,
+	}
+	c.Package = pm.Name
+	c.Logf = p.Logf
+	c.RegistryOptions = p.RegistryOptions
+	fbc, err := c.Run(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("read catalog bundle data: %v", err)
+	}
+	_, csv, err := bundleCSV(fbc, csvName)
+	if err != nil {
+		return nil, err
+	}
+	return &InstallPermissions{
+		CSVName:            csv.Name,
+		Permissions:        csv.Spec.InstallStrategy.StrategySpec.Permissions,
+		ClusterPermissions: csv.Spec.InstallStrategy.StrategySpec.ClusterPermissions,
+		OwnedCRDs:          csv.Spec.CustomResourceDefinitions.Owned,
+	}, nil
+}
+
+// scopedInstallRules are the rules an operator group service account needs
+// in its namespace for OLM to install an operator with it.
+var scopedInstallRules = []rbacv1.PolicyRule{
+	{
+		APIGroups: []string{v1alpha1.GroupName},
+		Resources: []string{"subscriptions", "clusterserviceversions"},
+		Verbs:     []string{"get", "create", "update", "patch"},
+	},
+	{
+		APIGroups: []string{corev1.GroupName},
+		Resources: []string{"services", "serviceaccounts", "configmaps", "secrets"},
+		Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+	},
+	{
+		APIGroups: []string{rbacv1.GroupName},
+		Resources: []string{"roles", "rolebindings"},
+		Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+	},
+	{
+		APIGroups: []string{"apps"},
+		Resources: []string{"deployments"},
+		Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+	},
+	{
+		APIGroups: []string{corev1.GroupName},
+		Resources: []string{"pods"},
+		Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+	},
+}
+
+// RBAC returns a service account and the roles and bindings that let it
+// install the operator in the namespace. Kubernetes only lets the service
+// account create the operator's roles if it holds their rules itself, so the
+// operator's own rules are included. Cluster roles are only returned if the
+// operator requests cluster permissions or owns CRDs.
+func (p *InstallPermissions) RBAC(namespace, serviceAccount string) []client.Object {
+	name := serviceAccount + "-install"
+	sa := &corev1.ServiceAccount{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+		ObjectMeta: metav1.ObjectMeta{Name: serviceAccount, Namespace: namespace},
+	}
+	subjects := []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: serviceAccount, Namespace: namespace}}
+
+	role := &rbacv1.Role{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Rules:      append(append([]rbacv1.PolicyRule{}, scopedInstallRules...), flattenRules(p.Permissions)...),
+	}
+	binding := &rbacv1.RoleBinding{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: name},
+		Subjects:   subjects,
+	}
+	objs := []client.Object{sa, role, binding}
+
+	var clusterRules []rbacv1.PolicyRule
+	if len(p.ClusterPermissions) > 0 {
+		clusterRules = append(clusterRules, rbacv1.PolicyRule{
+			APIGroups: []string{rbacv1.GroupName},
+			Resources: []string{"clusterroles", "clusterrolebindings"},
+			Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+		})
+		clusterRules = append(clusterRules, flattenRules(p.ClusterPermissions)...)
+	}
+	if len(p.OwnedCRDs) > 0 {
+		clusterRules = append(clusterRules, rbacv1.PolicyRule{
+			APIGroups: []string{apiextensionsv1.GroupName},
+			Resources: []string{"customresourcedefinitions"},
+			Verbs:     []string{"get", "list", "watch", "create", "update", "patch"},
+		})
+	}
+	if len(clusterRules) == 0 {
+		return objs
+	}
+
+	// cluster roles are not namespaced, so include the namespace in the name
+	clusterName := fmt.Sprintf("%s-%s", namespace, name)
+	clusterRole := &rbacv1.ClusterRole{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRole"},
+		ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+		Rules:      clusterRules,
+	}
+	clusterBinding := &rbacv1.ClusterRoleBinding{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRoleBinding"},
+		ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: clusterName},
+		Subjects:   subjects,
+	}
+	return append(objs, clusterRole, clusterBinding)
+}
+
+func flattenRules(perms []v1alpha1.StrategyDeploymentPermissions) []rbacv1.PolicyRule {
+	var rules []rbacv1.PolicyRule
+	for _, p := range perms {
+		rules = append(rules, p.Rules...)
+	}
+	return rules
+}
@@ -0,0 +1,110 @@
+package action_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("InstallPermissions", func() {
+	var perms internalaction.InstallPermissions
+
+	BeforeEach(func() {
+		perms = internalaction.InstallPermissions{
+			CSVName: "etcdoperator.v0.9.4",
+			Permissions: []v1alpha1.StrategyDeploymentPermissions{{
+				ServiceAccountName: "etcd-operator",
+				Rules: []rbacv1.PolicyRule{{
+					APIGroups: []string{"etcd.database.coreos.com"},
+					Resources: []string{"etcdclusters"},
+					Verbs:     []string{"*"},
+				}},
+			}},
+		}
+	})
+
+	It("should generate namespaced rbac for a namespaced operator", func() {
+		objs := perms.RBAC("etcd-namespace", "etcd-installer")
+		Expect(objs).To(HaveLen(3))
+
+		role, ok := objs[1].(*rbacv1.Role)
+		Expect(ok).To(BeTrue())
+		Expect(role.Namespace).To(Equal("etcd-namespace"))
+		Expect(role.Rules).To(ContainElement(perms.Permissions[0].Rules[0]))
+
+		binding, ok := objs[2].(*rbacv1.RoleBinding)
+		Expect(ok).To(BeTrue())
+		Expect(binding.RoleRef.Name).To(Equal(role.Name))
+		Expect(binding.Subjects).To(ConsistOf(rbacv1.Subject{Kind: "ServiceAccount", Name: "etcd-installer", Namespace: "etcd-namespace"}))
+	})
+
+	It("should generate cluster rbac for cluster permissions and owned crds", func() {
+		perms.ClusterPermissions = []v1alpha1.StrategyDeploymentPermissions{{
+			ServiceAccountName: "etcd-operator",
+			Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{""},
+				Resources: []string{"nodes"},
+				Verbs:     []string{"get"},
+			}},
+		}}
+		perms.OwnedCRDs = []v1alpha1.CRDDescription{{Name: "etcdclusters.etcd.database.coreos.com"}}
+
+		objs := perms.RBAC("etcd-namespace", "etcd-installer")
+		Expect(objs).To(HaveLen(5))
+
+		clusterRole, ok := objs[3].(*rbacv1.ClusterRole)
+		Expect(ok).To(BeTrue())
+		Expect(clusterRole.Name).To(Equal("etcd-namespace-etcd-installer-install"))
+		Expect(clusterRole.Rules).To(ContainElement(perms.ClusterPermissions[0].Rules[0]))
+		Expect(clusterRole.Rules).To(ContainElement(HaveField("Resources", ConsistOf("customresourcedefinitions"))))
+	})
+})
+
+var _ = Describe("OperatorInstall with a service account", func() {
+	It("should refuse an operator group with a different service account", func() {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+
+		pm := &operatorsv1.PackageManifest{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "etcd-namespace"},
+			Status: operatorsv1.PackageManifestStatus{
+				DefaultChannel: "stable",
+				Channels: []operatorsv1.PackageChannel{{
+					Name:       "stable",
+					CurrentCSV: "etcdoperator.v0.9.4",
+					CurrentCSVDesc: operatorsv1.CSVDescription{
+						InstallModes: []v1alpha1.InstallMode{{Type: v1alpha1.InstallModeTypeOwnNamespace, Supported: true}},
+					},
+				}},
+			},
+		}
+		og := &v1.OperatorGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd-namespace", Namespace: "etcd-namespace"},
+			Spec:       v1.OperatorGroupSpec{ServiceAccountName: "other"},
+			Status:     v1.OperatorGroupStatus{Namespaces: []string{"etcd-namespace"}},
+		}
+
+		var cfg action.Configuration
+		cfg.Scheme = sch
+		cfg.Client = fake.NewClientBuilder().WithObjects(pm, og).WithScheme(sch).Build()
+		cfg.Namespace = "etcd-namespace"
+
+		i := action.NewOperatorInstall(&cfg)
+		i.Package = "etcd"
+		i.ServiceAccount = "etcd-installer"
+		_, err = i.Run(context.TODO())
+		Expect(err).To(MatchError(ContainSubstring(`existing operator group "etcd-namespace" uses service account "other", not "etcd-installer"`)))
+	})
+})
@@ -0,0 +1,190 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/subscription"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// catalogSourceReady is the gRPC connection state of a reachable catalog source.
+const catalogSourceReady = "READY"
+
+// OperatorStatus joins the objects OLM creates for each installed operator to
+// report whether the operator is actually working.
+type OperatorStatus struct {
+	config *action.Configuration
+}
+
+func NewOperatorStatus(cfg *action.Configuration) *OperatorStatus {
+	return &OperatorStatus{cfg}
+}
+
+// OperatorHealth is the health of the operator installed by a subscription.
+type OperatorHealth struct {
+	Subscription  v1alpha1.Subscription
+	CSV           *v1alpha1.ClusterServiceVersion
+	InstallPlan   *v1alpha1.InstallPlan
+	CatalogSource *v1alpha1.CatalogSource
+
+	DeploymentsAvailable int32
+	DeploymentsDesired   int32
+
+	UpgradePending   bool
+	CatalogReachable bool
+
+	// Problems lists the reasons the operator is unhealthy.
+	Problems []string
+}
+
+func (h OperatorHealth) Healthy() bool {
+	return len(h.Problems) == 0
+}
+
+func (s *OperatorStatus) Run(ctx context.Context) ([]OperatorHealth, error) {
+	subs := v1alpha1.SubscriptionList{}
+	if err := s.config.Client.List(ctx, &subs, client.InNamespace(s.config.Namespace)); err != nil {
+		return nil, fmt.Errorf("list subscriptions: %v", err)
+	}
+
+	healths := make([]OperatorHealth, 0, len(subs.Items))
+	for _, sub := range subs.Items {
+		h, err := s.health(ctx, sub)
+		if err != nil {
+			return nil, fmt.Errorf("get status of subscription %q: %v", sub.Name, err)
+		}
+		healths = append(healths, *h)
+	}
+	return healths, nil
+}
+
+func (s *OperatorStatus) health(ctx context.Context, sub v1alpha1.Subscription) (*OperatorHealth, error) {
+	h := &OperatorHealth{Subscription: sub}
+
+	if err := s.checkCatalogSource(ctx, h); err != nil {
+		return nil, err
+	}
+	if err := s.checkInstallPlan(ctx, h); err != nil {
+		return nil, err
+	}
+	if err := s.checkCSV(ctx, h); err != nil {
+		return nil, err
+	}
+	if h.CSV != nil {
+		if err := s.checkDeployments(ctx, h); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+func (s *OperatorStatus) checkCatalogSource(ctx context.Context, h *OperatorHealth) error {
+	key := types.NamespacedName{
+		Namespace: h.Subscription.Spec.CatalogSourceNamespace,
+		Name:      h.Subscription.Spec.CatalogSource,
+	}
+	cs := &v1alpha1.CatalogSource{}
+	if err := s.config.Client.Get(ctx, key, cs); err != nil {
+		if apierrors.IsNotFound(err) {
+			h.Problems = append(h.Problems, fmt.Sprintf("catalogsource %q not found", key))
+			return nil
+		}
+		return fmt.Errorf("get catalogsource: %v", err)
+	}
+	h.CatalogSource = cs
+	h.CatalogReachable = cs.Status.GRPCConnectionState != nil && cs.Status.GRPCConnectionState.LastObservedState == catalogSourceReady
+	if !h.CatalogReachable {
+		h.Problems = append(h.Problems, fmt.Sprintf("catalogsource %q is not reachable", key))
+	}
+	return nil
+}
+
+func (s *OperatorStatus) checkInstallPlan(ctx context.Context, h *OperatorHealth) error {
+	sub := h.Subscription
+	h.UpgradePending = sub.Status.CurrentCSV != "" && sub.Status.CurrentCSV != sub.Status.InstalledCSV
+	if sub.Status.InstallPlanRef == nil {
+		return nil
+	}
+
+	key := types.NamespacedName{
+		Namespace: sub.Status.InstallPlanRef.Namespace,
+		Name:      sub.Status.InstallPlanRef.Name,
+	}
+	ip := &v1alpha1.InstallPlan{}
+	if err := s.config.Client.Get(ctx, key, ip); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("get install plan: %v", err)
+	}
+	h.InstallPlan = ip
+	if ip.Status.Phase == v1alpha1.InstallPlanPhaseFailed {
+		h.Problems = append(h.Problems, fmt.Sprintf("install plan %q failed", ip.Name))
+	}
+	if ip.Status.Phase == v1alpha1.InstallPlanPhaseRequiresApproval {
+		h.UpgradePending = true
+	}
+	return nil
+}
+
+func (s *OperatorStatus) checkCSV(ctx context.Context, h *OperatorHealth) error {
+	name := subscription.CSVName(&h.Subscription)
+	if name == "" {
+		h.Problems = append(h.Problems, "no csv installed")
+		return nil
+	}
+
+	key := types.NamespacedName{
+		Namespace: h.Subscription.Namespace,
+		Name:      name,
+	}
+	csv := &v1alpha1.ClusterServiceVersion{}
+	if err := s.config.Client.Get(ctx, key, csv); err != nil {
+		if apierrors.IsNotFound(err) {
+			h.Problems = append(h.Problems, fmt.Sprintf("csv %q not found", name))
+			return nil
+		}
+		return fmt.Errorf("get clusterserviceversion: %v", err)
+	}
+	h.CSV = csv
+	if csv.Status.Phase != v1alpha1.CSVPhaseSucceeded {
+		h.Problems = append(h.Problems, fmt.Sprintf("csv %q is in phase %q", name, csv.Status.Phase))
+	}
+	return nil
+}
+
+func (s *OperatorStatus) checkDeployments(ctx context.Context, h *OperatorHealth) error {
+	for _, spec := range h.CSV.Spec.InstallStrategy.StrategySpec.DeploymentSpecs {
+		desired := int32(1)
+		if spec.Spec.Replicas != nil {
+			desired = *spec.Spec.Replicas
+		}
+		h.DeploymentsDesired += desired
+
+		key := types.NamespacedName{
+			Namespace: h.CSV.Namespace,
+			Name:      spec.Name,
+		}
+		dep := &appsv1.Deployment{}
+		if err := s.config.Client.Get(ctx, key, dep); err != nil {
+			if apierrors.IsNotFound(err) {
+				h.Problems = append(h.Problems, fmt.Sprintf("deployment %q not found", spec.Name))
+				continue
+			}
+			return fmt.Errorf("get deployment: %v", err)
+		}
+		h.DeploymentsAvailable += dep.Status.AvailableReplicas
+		if dep.Status.AvailableReplicas < desired {
+			h.Problems = append(h.Problems, fmt.Sprintf("deployment %q has %d/%d replicas available", spec.Name, dep.Status.AvailableReplicas, desired))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package action_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("OperatorStatus", func() {
+	var (
+		cfg action.Configuration
+		cs  *v1alpha1.CatalogSource
+		sub *v1alpha1.Subscription
+		csv *v1alpha1.ClusterServiceVersion
+		dep *appsv1.Deployment
+	)
+
+	BeforeEach(func() {
+		cs = &v1alpha1.CatalogSource{
+			ObjectMeta: metav1.ObjectMeta{Name: "operatorhubio", Namespace: "olm"},
+			Status: v1alpha1.CatalogSourceStatus{
+				GRPCConnectionState: &v1alpha1.GRPCConnectionState{LastObservedState: "READY"},
+			},
+		}
+		sub = &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "etcd-namespace"},
+			Spec: &v1alpha1.SubscriptionSpec{
+				Package:                "etcd",
+				CatalogSource:          "operatorhubio",
+				CatalogSourceNamespace: "olm",
+			},
+			Status: v1alpha1.SubscriptionStatus{
+				InstalledCSV: "etcdoperator.v0.9.4",
+				CurrentCSV:   "etcdoperator.v0.9.4",
+			},
+		}
+		csv = &v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcdoperator.v0.9.4", Namespace: "etcd-namespace"},
+			Spec: v1alpha1.ClusterServiceVersionSpec{
+				InstallStrategy: v1alpha1.NamedInstallStrategy{
+					StrategySpec: v1alpha1.StrategyDetailsDeployment{
+						DeploymentSpecs: []v1alpha1.StrategyDeploymentSpec{{Name: "etcd-operator"}},
+					},
+				},
+			},
+			Status: v1alpha1.ClusterServiceVersionStatus{Phase: v1alpha1.CSVPhaseSucceeded},
+		}
+		dep = &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd-operator", Namespace: "etcd-namespace"},
+			Status:     appsv1.DeploymentStatus{AvailableReplicas: 1},
+		}
+	})
+
+	run := func() []internalaction.OperatorHealth {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+		cfg.Scheme = sch
+		cfg.Client = fake.NewClientBuilder().WithObjects(cs, sub, csv, dep).WithScheme(sch).Build()
+		cfg.Namespace = "etcd-namespace"
+
+		healths, err := internalaction.NewOperatorStatus(&cfg).Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(healths).To(HaveLen(1))
+		return healths
+	}
+
+	It("should report a working operator as healthy", func() {
+		h := run()[0]
+		Expect(h.Healthy()).To(BeTrue())
+		Expect(h.CatalogReachable).To(BeTrue())
+		Expect(h.UpgradePending).To(BeFalse())
+		Expect(h.DeploymentsAvailable).To(Equal(int32(1)))
+		Expect(h.DeploymentsDesired).To(Equal(int32(1)))
+	})
+
+	It("should report an upgrade without becoming unhealthy", func() {
+		sub.Status.CurrentCSV = "etcdoperator.v0.9.5"
+		h := run()[0]
+		Expect(h.Healthy()).To(BeTrue())
+		Expect(h.UpgradePending).To(BeTrue())
+	})
+
+	It("should report a failed csv, unavailable deployment and unreachable catalog", func() {
+		csv.Status.Phase = v1alpha1.CSVPhaseFailed
+		dep.Status.AvailableReplicas = 0
+		cs.Status.GRPCConnectionState.LastObservedState = "TRANSIENT_FAILURE"
+		h := run()[0]
+		Expect(h.Healthy()).To(BeFalse())
+		Expect(h.CatalogReachable).To(BeFalse())
+		Expect(h.Problems).To(ConsistOf(
+			`catalogsource "olm/operatorhubio" is not reachable`,
+			`csv "etcdoperator.v0.9.4" is in phase "Failed"`,
+			`deployment "etcd-operator" has 0/1 replicas available`,
+		))
+	})
+})
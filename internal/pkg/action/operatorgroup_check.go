@@ -0,0 +1,117 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/subscription"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// OperatorGroupCheck finds namespaces with subscriptions but no operator
+// group, and namespaces with more than one operator group. OLM cannot install
+// operators in either: with no operator group, install plans are never
+// created, and with several, CSVs fail with TooManyOperatorGroups.
+type OperatorGroupCheck struct {
+	config *action.Configuration
+}
+
+func NewOperatorGroupCheck(cfg *action.Configuration) *OperatorGroupCheck {
+	return &OperatorGroupCheck{cfg}
+}
+
+// NamespaceOperatorGroups is a namespace with a broken operator group setup.
+type NamespaceOperatorGroups struct {
+	Namespace      string
+	OperatorGroups []v1.OperatorGroup
+	Subscriptions  []SubscriptionOperatorGroup
+}
+
+// SubscriptionOperatorGroup is a subscription and the operator group its
+// installed CSV was last configured by, if any.
+type SubscriptionOperatorGroup struct {
+	Subscription  v1alpha1.Subscription
+	CSV           string
+	OperatorGroup string
+}
+
+// Impact explains what happens to the operators in the namespace.
+func (n NamespaceOperatorGroups) Impact() string {
+	if len(n.OperatorGroups) == 0 {
+		return fmt.Sprintf("namespace %q has %d subscription(s) but no operator group; OLM will not install or upgrade its operators", n.Namespace, len(n.Subscriptions))
+	}
+	return fmt.Sprintf("namespace %q has %d operator groups; CSVs in the namespace go to phase Failed with reason TooManyOperatorGroups", n.Namespace, len(n.OperatorGroups))
+}
+
+// DependentSubscriptions returns the subscriptions whose CSV was configured
+// by the named operator group.
+func (n NamespaceOperatorGroups) DependentSubscriptions(operatorGroup string) []string {
+	var subs []string
+	for _, s := range n.Subscriptions {
+		if s.OperatorGroup == operatorGroup {
+			subs = append(subs, s.Subscription.Name)
+		}
+	}
+	return subs
+}
+
+func (c *OperatorGroupCheck) Run(ctx context.Context) ([]NamespaceOperatorGroups, error) {
+	ogs := v1.OperatorGroupList{}
+	if err := c.config.Client.List(ctx, &ogs, client.InNamespace(c.config.Namespace)); err != nil {
+		return nil, fmt.Errorf("list operator groups: %v", err)
+	}
+	subs := v1alpha1.SubscriptionList{}
+	if err := c.config.Client.List(ctx, &subs, client.InNamespace(c.config.Namespace)); err != nil {
+		return nil, fmt.Errorf("list subscriptions: %v", err)
+	}
+	csvs := v1alpha1.ClusterServiceVersionList{}
+	if err := c.config.Client.List(ctx, &csvs, client.InNamespace(c.config.Namespace)); err != nil {
+		return nil, fmt.Errorf("list clusterserviceversions: %v", err)
+	}
+
+	byNamespace := map[string]*NamespaceOperatorGroups{}
+	get := func(ns string) *NamespaceOperatorGroups {
+		if _, ok := byNamespace[ns]; !ok {
+			byNamespace[ns] = &NamespaceOperatorGroups{Namespace: ns}
+		}
+		return byNamespace[ns]
+	}
+	for _, og := range ogs.Items {
+		n := get(og.Namespace)
+		n.OperatorGroups = append(n.OperatorGroups, og)
+	}
+
+	csvGroups := map[string]string{}
+	for _, csv := range csvs.Items {
+		if !csv.IsCopied() {
+			csvGroups[csv.Namespace+"/"+csv.Name] = csv.GetAnnotations()[v1.OperatorGroupAnnotationKey]
+		}
+	}
+	for _, sub := range subs.Items {
+		sub := sub
+		n := get(sub.Namespace)
+		csv := subscription.CSVName(&sub)
+		n.Subscriptions = append(n.Subscriptions, SubscriptionOperatorGroup{
+			Subscription:  sub,
+			CSV:           csv,
+			OperatorGroup: csvGroups[sub.Namespace+"/"+csv],
+		})
+	}
+
+	var broken []NamespaceOperatorGroups
+	for _, n := range byNamespace {
+		if len(n.OperatorGroups) > 1 || (len(n.OperatorGroups) == 0 && len(n.Subscriptions) > 0) {
+			broken = append(broken, *n)
+		}
+	}
+	sort.Slice(broken, func(i, j int) bool {
+		return broken[i].Namespace < broken[j].Namespace
+	})
+	return broken, nil
+}
@@ -0,0 +1,81 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/cluster"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+type OperatorGroupCreate struct {
+	config *action.Configuration
+
+	// Name defaults to the namespace name.
+	Name               string
+	TargetNamespaces   []string
+	Selector           string
+	UpgradeStrategy    string
+	ServiceAccountName string
+}
+
+func NewOperatorGroupCreate(cfg *action.Configuration) *OperatorGroupCreate {
+	return &OperatorGroupCreate{
+		config: cfg,
+	}
+}
+
+func (c *OperatorGroupCreate) Run(ctx context.Context) (*v1.OperatorGroup, error) {
+	ogs := v1.OperatorGroupList{}
+	if err := c.config.Client.List(ctx, &ogs, client.InNamespace(c.config.Namespace)); err != nil {
+		return nil, fmt.Errorf("list operator groups: %v", err)
+	}
+	name := c.Name
+	if name == "" {
+		name = c.config.Namespace
+	}
+	// Re-applying the same operator group updates it in place.
+	for _, og := range ogs.Items {
+		if og.Name != name {
+			return nil, fmt.Errorf("namespace %q already has operator group %q; a namespace may only have one operator group", c.config.Namespace, og.Name)
+		}
+	}
+
+	strategy, err := parseUpgradeStrategy(c.UpgradeStrategy)
+	if err != nil {
+		return nil, err
+	}
+	selector, err := parseTargetSelector(c.TargetNamespaces, c.Selector)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTargetsCompatible(ctx, c.config, c.TargetNamespaces, selector); err != nil {
+		return nil, err
+	}
+
+	og := &v1.OperatorGroup{}
+	og.SetName(name)
+	og.SetNamespace(c.config.Namespace)
+	og.Spec.TargetNamespaces = c.TargetNamespaces
+	og.Spec.Selector = selector
+	og.Spec.UpgradeStrategy = strategy
+	og.Spec.ServiceAccountName = c.ServiceAccountName
+
+	if err := cluster.Apply(ctx, c.config.Client, c.config.Scheme, og); err != nil {
+		return nil, fmt.Errorf("apply operator group: %v", err)
+	}
+	return og, nil
+}
+
+func parseUpgradeStrategy(s string) (v1.UpgradeStrategy, error) {
+	switch strategy := v1.UpgradeStrategy(s); strategy {
+	case "", v1.UpgradeStrategyDefault, v1.UpgradeStrategyUnsafeFailForward:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("invalid upgrade strategy %q, expected one of %s|%s", s, v1.UpgradeStrategyDefault, v1.UpgradeStrategyUnsafeFailForward)
+	}
+}
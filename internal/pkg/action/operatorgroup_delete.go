@@ -0,0 +1,51 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/cluster"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+type OperatorGroupDelete struct {
+	config *action.Configuration
+
+	Name string
+
+	// Force deletes the operator group even if operators are still
+	// subscribed in the namespace.
+	Force bool
+}
+
+func NewOperatorGroupDelete(cfg *action.Configuration) *OperatorGroupDelete {
+	return &OperatorGroupDelete{
+		config: cfg,
+	}
+}
+
+func (d *OperatorGroupDelete) Run(ctx context.Context) error {
+	og, err := getOperatorGroup(ctx, d.config, d.Name)
+	if err != nil {
+		return err
+	}
+	if !d.Force {
+		subs := v1alpha1.SubscriptionList{}
+		if err := d.config.Client.List(ctx, &subs, client.InNamespace(d.config.Namespace)); err != nil {
+			return fmt.Errorf("list subscriptions: %v", err)
+		}
+		if len(subs.Items) > 0 {
+			return fmt.Errorf("namespace %q still has %d subscription(s); uninstall them first or use --force", d.config.Namespace, len(subs.Items))
+		}
+	}
+	og.SetGroupVersionKind(v1.GroupVersion.WithKind(v1.OperatorGroupKind))
+	if err := d.config.Client.Delete(ctx, og); err != nil {
+		return fmt.Errorf("delete operator group %q: %v", og.Name, err)
+	}
+	return cluster.WaitForDeletion(ctx, d.config.Client, og)
+}
@@ -0,0 +1,53 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+type OperatorGroupDescribe struct {
+	config *action.Configuration
+
+	Name string
+}
+
+func NewOperatorGroupDescribe(cfg *action.Configuration) *OperatorGroupDescribe {
+	return &OperatorGroupDescribe{
+		config: cfg,
+	}
+}
+
+// OperatorGroupDescription is an operator group and the operators installed
+// in its namespace.
+type OperatorGroupDescription struct {
+	OperatorGroup v1.OperatorGroup
+	CSVs          []v1alpha1.ClusterServiceVersion
+}
+
+func (d *OperatorGroupDescribe) Run(ctx context.Context) (*OperatorGroupDescription, error) {
+	og, err := getOperatorGroup(ctx, d.config, d.Name)
+	if err != nil {
+		return nil, err
+	}
+	csvs, err := installedCSVs(ctx, d.config)
+	if err != nil {
+		return nil, err
+	}
+	return &OperatorGroupDescription{OperatorGroup: *og, CSVs: csvs}, nil
+}
+
+func getOperatorGroup(ctx context.Context, cfg *action.Configuration, name string) (*v1.OperatorGroup, error) {
+	og := &v1.OperatorGroup{}
+	key := types.NamespacedName{Namespace: cfg.Namespace, Name: name}
+	if err := cfg.Client.Get(ctx, key, og); err != nil {
+		return nil, fmt.Errorf("get operator group %q: %v", name, err)
+	}
+	return og, nil
+}
@@ -0,0 +1,28 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+type OperatorGroupList struct {
+	config *action.Configuration
+}
+
+func NewOperatorGroupList(cfg *action.Configuration) *OperatorGroupList {
+	return &OperatorGroupList{cfg}
+}
+
+func (l *OperatorGroupList) Run(ctx context.Context) ([]v1.OperatorGroup, error) {
+	ogs := v1.OperatorGroupList{}
+	if err := l.config.Client.List(ctx, &ogs, client.InNamespace(l.config.Namespace)); err != nil {
+		return nil, fmt.Errorf("list operator groups: %v", err)
+	}
+	return ogs.Items, nil
+}
@@ -0,0 +1,149 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// OperatorGroupResolve leaves a namespace with exactly one operator group. If
+// the namespace has none, an operator group compatible with the operators
+// installed in it is created. If it has several, Keep is kept and the others
+// are deleted.
+type OperatorGroupResolve struct {
+	config *action.Configuration
+
+	Keep string
+
+	// Merge sets the targets of the kept operator group to the union of the
+	// targets of all the operator groups in the namespace.
+	Merge bool
+
+	// Force keeps or merges operator groups even if operators already
+	// installed in the namespace do not support the resulting install mode.
+	Force bool
+
+	Logf func(string, ...interface{})
+}
+
+func NewOperatorGroupResolve(cfg *action.Configuration) *OperatorGroupResolve {
+	return &OperatorGroupResolve{
+		config: cfg,
+		Logf:   func(string, ...interface{}) {},
+	}
+}
+
+func (r *OperatorGroupResolve) Run(ctx context.Context) (*v1.OperatorGroup, error) {
+	ogs := v1.OperatorGroupList{}
+	if err := r.config.Client.List(ctx, &ogs, client.InNamespace(r.config.Namespace)); err != nil {
+		return nil, fmt.Errorf("list operator groups: %v", err)
+	}
+
+	switch len(ogs.Items) {
+	case 0:
+		return r.adopt(ctx)
+	case 1:
+		r.Logf("namespace %q already has a single operator group %q", r.config.Namespace, ogs.Items[0].Name)
+		return &ogs.Items[0], nil
+	}
+
+	names := make([]string, 0, len(ogs.Items))
+	var keep *v1.OperatorGroup
+	for i := range ogs.Items {
+		names = append(names, ogs.Items[i].Name)
+		if ogs.Items[i].Name == r.Keep {
+			keep = &ogs.Items[i]
+		}
+	}
+	if keep == nil {
+		return nil, fmt.Errorf("namespace %q has operator groups %q; choose the one to keep", r.config.Namespace, strings.Join(names, ","))
+	}
+
+	targets, selector := keep.Spec.TargetNamespaces, keep.Spec.Selector
+	if r.Merge {
+		var err error
+		if targets, err = mergeTargets(ogs.Items); err != nil {
+			return nil, err
+		}
+		selector = nil
+	}
+	if !r.Force {
+		if err := checkTargetsCompatible(ctx, r.config, targets, selector); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.Merge {
+		if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+			if err := r.config.Client.Get(ctx, objectKeyForObject(keep), keep); err != nil {
+				return err
+			}
+			keep.Spec.TargetNamespaces = targets
+			keep.Spec.Selector = nil
+			return r.config.Client.Update(ctx, keep)
+		}); err != nil {
+			return nil, fmt.Errorf("update operator group %q: %v", keep.Name, err)
+		}
+		r.Logf("operatorgroup %q targets merged", keep.Name)
+	}
+
+	for _, og := range ogs.Items {
+		og := og
+		if og.Name == keep.Name {
+			continue
+		}
+		og.SetGroupVersionKind(v1.GroupVersion.WithKind(v1.OperatorGroupKind))
+		if err := r.config.Client.Delete(ctx, &og); err != nil {
+			return nil, fmt.Errorf("delete operator group %q: %v", og.Name, err)
+		}
+		r.Logf("operatorgroup %q deleted", og.Name)
+	}
+	return keep, nil
+}
+
+// adopt creates an operator group for a namespace that has none, targeting
+// the namespace itself if the installed operators support it, and all
+// namespaces otherwise.
+func (r *OperatorGroupResolve) adopt(ctx context.Context) (*v1.OperatorGroup, error) {
+	targets := []string{r.config.Namespace}
+	if err := checkTargetsCompatible(ctx, r.config, targets, nil); err != nil {
+		targets = nil
+		if err := checkTargetsCompatible(ctx, r.config, targets, nil); err != nil {
+			return nil, fmt.Errorf("no operator group targets are compatible with the installed operators: %v", err)
+		}
+	}
+
+	c := NewOperatorGroupCreate(r.config)
+	c.TargetNamespaces = targets
+	og, err := c.Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.Logf("operatorgroup %q created", og.Name)
+	return og, nil
+}
+
+// mergeTargets returns the union of the target namespaces of the operator
+// groups. If any operator group targets all namespaces, so does the union.
+func mergeTargets(ogs []v1.OperatorGroup) ([]string, error) {
+	targets := sets.New[string]()
+	for _, og := range ogs {
+		if og.Spec.Selector != nil && len(og.Spec.TargetNamespaces) == 0 {
+			return nil, fmt.Errorf("cannot merge operator group %q: its targets are chosen by a selector", og.Name)
+		}
+		if len(og.Spec.TargetNamespaces) == 0 || sets.New(og.Spec.TargetNamespaces...).Has(corev1.NamespaceAll) {
+			return nil, nil
+		}
+		targets.Insert(og.Spec.TargetNamespaces...)
+	}
+	return sets.List(targets), nil
+}
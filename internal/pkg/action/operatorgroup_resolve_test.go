@@ -0,0 +1,143 @@
+package action_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("OperatorGroupCheck and OperatorGroupResolve", func() {
+	var (
+		cfg  action.Configuration
+		objs []client.Object
+	)
+
+	BeforeEach(func() {
+		objs = []client.Object{
+			&v1alpha1.Subscription{
+				ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "etcd-namespace"},
+				Spec:       &v1alpha1.SubscriptionSpec{Package: "etcd"},
+				Status:     v1alpha1.SubscriptionStatus{InstalledCSV: "etcdoperator.v0.9.4"},
+			},
+			&v1alpha1.ClusterServiceVersion{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "etcdoperator.v0.9.4",
+					Namespace:   "etcd-namespace",
+					Annotations: map[string]string{v1.OperatorGroupAnnotationKey: "first"},
+				},
+				Spec: v1alpha1.ClusterServiceVersionSpec{
+					InstallModes: []v1alpha1.InstallMode{
+						{Type: v1alpha1.InstallModeTypeOwnNamespace, Supported: true},
+						{Type: v1alpha1.InstallModeTypeMultiNamespace, Supported: true},
+					},
+				},
+			},
+			// a healthy namespace must not be reported
+			&v1.OperatorGroup{ObjectMeta: metav1.ObjectMeta{Name: "healthy", Namespace: "healthy-namespace"}},
+		}
+	})
+
+	build := func() {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+		cfg.Scheme = sch
+		cfg.Client = fake.NewClientBuilder().WithObjects(objs...).WithScheme(sch).WithInterceptorFuncs(applyFuncs).Build()
+		cfg.Namespace = "etcd-namespace"
+	}
+
+	listGroups := func() []v1.OperatorGroup {
+		ogs := v1.OperatorGroupList{}
+		Expect(cfg.Client.List(context.TODO(), &ogs, client.InNamespace("etcd-namespace"))).To(Succeed())
+		return ogs.Items
+	}
+
+	Context("with no operator group", func() {
+		It("should report the namespace", func() {
+			build()
+			cfg.Namespace = corev1.NamespaceAll
+			broken, err := internalaction.NewOperatorGroupCheck(&cfg).Run(context.TODO())
+			Expect(err).To(BeNil())
+			Expect(broken).To(HaveLen(1))
+			Expect(broken[0].Namespace).To(Equal("etcd-namespace"))
+			Expect(broken[0].Impact()).To(ContainSubstring("no operator group"))
+		})
+
+		It("should adopt the namespace with a compatible operator group", func() {
+			build()
+			og, err := internalaction.NewOperatorGroupResolve(&cfg).Run(context.TODO())
+			Expect(err).To(BeNil())
+			Expect(og.Spec.TargetNamespaces).To(Equal([]string{"etcd-namespace"}))
+			Expect(listGroups()).To(HaveLen(1))
+		})
+	})
+
+	Context("with several operator groups", func() {
+		BeforeEach(func() {
+			objs = append(objs,
+				&v1.OperatorGroup{
+					ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: "etcd-namespace"},
+					Spec:       v1.OperatorGroupSpec{TargetNamespaces: []string{"etcd-namespace"}},
+				},
+				&v1.OperatorGroup{
+					ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: "etcd-namespace"},
+					Spec:       v1.OperatorGroupSpec{TargetNamespaces: []string{"team-a"}},
+				},
+			)
+		})
+
+		It("should report the dependent subscriptions of each group", func() {
+			build()
+			broken, err := internalaction.NewOperatorGroupCheck(&cfg).Run(context.TODO())
+			Expect(err).To(BeNil())
+			Expect(broken).To(HaveLen(1))
+			Expect(broken[0].Impact()).To(ContainSubstring("TooManyOperatorGroups"))
+			Expect(broken[0].DependentSubscriptions("first")).To(ConsistOf("etcd"))
+			Expect(broken[0].DependentSubscriptions("second")).To(BeEmpty())
+		})
+
+		It("should require a group to keep", func() {
+			build()
+			_, err := internalaction.NewOperatorGroupResolve(&cfg).Run(context.TODO())
+			Expect(err).To(MatchError(ContainSubstring(`has operator groups "first,second"; choose the one to keep`)))
+			Expect(listGroups()).To(HaveLen(2))
+		})
+
+		It("should keep one group and delete the others", func() {
+			build()
+			r := internalaction.NewOperatorGroupResolve(&cfg)
+			r.Keep = "first"
+			_, err := r.Run(context.TODO())
+			Expect(err).To(BeNil())
+
+			ogs := listGroups()
+			Expect(ogs).To(HaveLen(1))
+			Expect(ogs[0].Name).To(Equal("first"))
+			Expect(ogs[0].Spec.TargetNamespaces).To(Equal([]string{"etcd-namespace"}))
+		})
+
+		It("should merge the targets into the kept group", func() {
+			build()
+			r := internalaction.NewOperatorGroupResolve(&cfg)
+			r.Keep = "first"
+			r.Merge = true
+			_, err := r.Run(context.TODO())
+			Expect(err).To(BeNil())
+
+			ogs := listGroups()
+			Expect(ogs).To(HaveLen(1))
+			Expect(ogs[0].Spec.TargetNamespaces).To(Equal([]string{"etcd-namespace", "team-a"}))
+		})
+	})
+})
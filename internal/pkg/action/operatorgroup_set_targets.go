@@ -0,0 +1,167 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/operator"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// OperatorGroupSetTargets changes the target namespaces of an operator group.
+// With neither TargetNamespaces nor Selector set, the operator group targets
+// all namespaces.
+type OperatorGroupSetTargets struct {
+	config *action.Configuration
+
+	Name             string
+	TargetNamespaces []string
+	Selector         string
+
+	// Force changes the targets even if operators already installed in the
+	// namespace do not support the resulting install mode, or if Selector
+	// matches no namespaces yet.
+	Force bool
+}
+
+func NewOperatorGroupSetTargets(cfg *action.Configuration) *OperatorGroupSetTargets {
+	return &OperatorGroupSetTargets{
+		config: cfg,
+	}
+}
+
+func (s *OperatorGroupSetTargets) Run(ctx context.Context) (*v1.OperatorGroup, error) {
+	og, err := getOperatorGroup(ctx, s.config, s.Name)
+	if err != nil {
+		return nil, err
+	}
+	selector, err := parseTargetSelector(s.TargetNamespaces, s.Selector)
+	if err != nil {
+		return nil, err
+	}
+	if !s.Force {
+		if err := checkTargetsCompatible(ctx, s.config, s.TargetNamespaces, selector); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := s.config.Client.Get(ctx, objectKeyForObject(og), og); err != nil {
+			return err
+		}
+		og.Spec.TargetNamespaces = s.TargetNamespaces
+		og.Spec.Selector = selector
+		return s.config.Client.Update(ctx, og)
+	}); err != nil {
+		return nil, fmt.Errorf("update operator group %q: %v", og.Name, err)
+	}
+	return og, nil
+}
+
+func parseTargetSelector(targetNamespaces []string, selector string) (*metav1.LabelSelector, error) {
+	if selector == "" {
+		return nil, nil
+	}
+	if len(targetNamespaces) > 0 {
+		return nil, fmt.Errorf("target namespaces and selector are mutually exclusive")
+	}
+	ls, err := metav1.ParseToLabelSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("parse selector %q: %v", selector, err)
+	}
+	return ls, nil
+}
+
+// resolveTargetNamespaces returns the namespaces an operator group with the
+// given targets would watch, as OLM reports them in the operator group status.
+// A selector that matches no namespaces is an error: the operator group would
+// watch nothing, while an empty list of namespaces means all of them.
+func resolveTargetNamespaces(ctx context.Context, cfg *action.Configuration, targetNamespaces []string, selector *metav1.LabelSelector) ([]string, error) {
+	if len(targetNamespaces) > 0 {
+		return targetNamespaces, nil
+	}
+	if selector == nil {
+		return []string{corev1.NamespaceAll}, nil
+	}
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("parse selector: %v", err)
+	}
+	nss := corev1.NamespaceList{}
+	if err := cfg.Client.List(ctx, &nss, client.MatchingLabelsSelector{Selector: sel}); err != nil {
+		return nil, fmt.Errorf("list namespaces: %v", err)
+	}
+	if len(nss.Items) == 0 {
+		return nil, fmt.Errorf("selector %q matches no namespaces, so the operator group would not target any namespace", metav1.FormatLabelSelector(selector))
+	}
+	names := make([]string, 0, len(nss.Items))
+	for _, ns := range nss.Items {
+		names = append(names, ns.Name)
+	}
+	return names, nil
+}
+
+// checkTargetsCompatible returns an error naming the operators installed in
+// the namespace that do not support the install mode of the given targets.
+func checkTargetsCompatible(ctx context.Context, cfg *action.Configuration, targetNamespaces []string, selector *metav1.LabelSelector) error {
+	namespaces, err := resolveTargetNamespaces(ctx, cfg, targetNamespaces, selector)
+	if err != nil {
+		return err
+	}
+	csvs, err := installedCSVs(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	desired := operator.PossibleInstallModes(cfg.Namespace, namespaces)
+	var broken []string
+	for _, csv := range csvs {
+		if supportedInstallModes(csv).Intersection(desired).Len() == 0 {
+			broken = append(broken, csv.Name)
+		}
+	}
+	if len(broken) > 0 {
+		return fmt.Errorf("install modes %q are not supported by installed operators %q; use --force to change the targets anyway",
+			strings.Join(sets.List(desired), ","),
+			strings.Join(broken, ","),
+		)
+	}
+	return nil
+}
+
+// installedCSVs returns the CSVs installed in the namespace, excluding copies
+// of CSVs installed in other namespaces.
+func installedCSVs(ctx context.Context, cfg *action.Configuration) ([]v1alpha1.ClusterServiceVersion, error) {
+	csvs := v1alpha1.ClusterServiceVersionList{}
+	if err := cfg.Client.List(ctx, &csvs, client.InNamespace(cfg.Namespace)); err != nil {
+		return nil, fmt.Errorf("list clusterserviceversions: %v", err)
+	}
+	installed := make([]v1alpha1.ClusterServiceVersion, 0, len(csvs.Items))
+	for _, csv := range csvs.Items {
+		csv := csv
+		if !csv.IsCopied() {
+			installed = append(installed, csv)
+		}
+	}
+	return installed, nil
+}
+
+func supportedInstallModes(csv v1alpha1.ClusterServiceVersion) sets.Set[string] {
+	modes := sets.New[string]()
+	for _, im := range csv.Spec.InstallModes {
+		if im.Supported {
+			modes.Insert(string(im.Type))
+		}
+	}
+	return modes
+}
@@ -0,0 +1,224 @@
+package action_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("OperatorGroup", func() {
+	var (
+		cfg   action.Configuration
+		objs  []client.Object
+		funcs interceptor.Funcs
+	)
+
+	BeforeEach(func() {
+		funcs = applyFuncs
+		objs = []client.Object{
+			&v1alpha1.ClusterServiceVersion{
+				ObjectMeta: metav1.ObjectMeta{Name: "etcdoperator.v0.9.4", Namespace: "etcd-namespace"},
+				Spec: v1alpha1.ClusterServiceVersionSpec{
+					InstallModes: []v1alpha1.InstallMode{
+						{Type: v1alpha1.InstallModeTypeOwnNamespace, Supported: true},
+						{Type: v1alpha1.InstallModeTypeSingleNamespace, Supported: true},
+						{Type: v1alpha1.InstallModeTypeAllNamespaces, Supported: false},
+					},
+				},
+			},
+			// copied csvs are installed in another namespace and must be ignored
+			&v1alpha1.ClusterServiceVersion{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "global.v1.0.0",
+					Namespace: "etcd-namespace",
+					Labels:    map[string]string{v1alpha1.CopiedLabelKey: "operators"},
+				},
+			},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"team": "a"}}},
+		}
+	})
+
+	build := func() {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+		cfg.Scheme = sch
+		cfg.Client = fake.NewClientBuilder().WithObjects(objs...).WithScheme(sch).WithInterceptorFuncs(funcs).Build()
+		cfg.Namespace = "etcd-namespace"
+	}
+
+	get := func(name string) *v1.OperatorGroup {
+		og := &v1.OperatorGroup{}
+		Expect(cfg.Client.Get(context.TODO(), types.NamespacedName{Namespace: "etcd-namespace", Name: name}, og)).To(Succeed())
+		return og
+	}
+
+	Context("create", func() {
+		It("should create an operator group named after the namespace", func() {
+			build()
+			c := internalaction.NewOperatorGroupCreate(&cfg)
+			c.TargetNamespaces = []string{"etcd-namespace"}
+			c.UpgradeStrategy = "TechPreviewUnsafeFailForward"
+			c.ServiceAccountName = "etcd-installer"
+			_, err := c.Run(context.TODO())
+			Expect(err).To(BeNil())
+
+			og := get("etcd-namespace")
+			Expect(og.Spec.TargetNamespaces).To(Equal([]string{"etcd-namespace"}))
+			Expect(og.Spec.UpgradeStrategy).To(Equal(v1.UpgradeStrategyUnsafeFailForward))
+			Expect(og.Spec.ServiceAccountName).To(Equal("etcd-installer"))
+		})
+
+		It("should update an operator group it applied before", func() {
+			build()
+			c := internalaction.NewOperatorGroupCreate(&cfg)
+			c.TargetNamespaces = []string{"etcd-namespace"}
+			_, err := c.Run(context.TODO())
+			Expect(err).To(BeNil())
+
+			c.TargetNamespaces = []string{"team-a"}
+			_, err = c.Run(context.TODO())
+			Expect(err).To(BeNil())
+			Expect(get("etcd-namespace").Spec.TargetNamespaces).To(Equal([]string{"team-a"}))
+		})
+
+		It("should report fields owned by another field manager", func() {
+			funcs.Patch = func(context.Context, client.WithWatch, client.Object, client.Patch, ...client.PatchOption) error {
+				return apierrors.NewApplyConflict(nil, `Apply failed with 1 conflict: conflict with "olm": .spec.targetNamespaces`)
+			}
+			build()
+			c := internalaction.NewOperatorGroupCreate(&cfg)
+			c.TargetNamespaces = []string{"etcd-namespace"}
+			_, err := c.Run(context.TODO())
+			Expect(err).To(MatchError(And(
+				ContainSubstring(`operatorgroup "etcd-namespace" has fields managed by another field manager`),
+				ContainSubstring(`conflict with "olm": .spec.targetNamespaces`),
+			)))
+		})
+
+		It("should refuse a second operator group", func() {
+			objs = append(objs, &v1.OperatorGroup{ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "etcd-namespace"}})
+			build()
+			c := internalaction.NewOperatorGroupCreate(&cfg)
+			c.Name = "second"
+			c.TargetNamespaces = []string{"etcd-namespace"}
+			_, err := c.Run(context.TODO())
+			Expect(err).To(MatchError(ContainSubstring(`already has operator group "existing"`)))
+		})
+
+		It("should refuse targets unsupported by installed operators", func() {
+			build()
+			_, err := internalaction.NewOperatorGroupCreate(&cfg).Run(context.TODO())
+			Expect(err).To(MatchError(ContainSubstring(`are not supported by installed operators "etcdoperator.v0.9.4"`)))
+		})
+
+		It("should refuse a selector that matches no namespaces", func() {
+			build()
+			c := internalaction.NewOperatorGroupCreate(&cfg)
+			c.Selector = "team=z"
+			_, err := c.Run(context.TODO())
+			Expect(err).To(MatchError(ContainSubstring(`selector "team=z" matches no namespaces`)))
+
+			ogs := &v1.OperatorGroupList{}
+			Expect(cfg.Client.List(context.TODO(), ogs)).To(Succeed())
+			Expect(ogs.Items).To(BeEmpty())
+		})
+
+		It("should refuse an invalid upgrade strategy", func() {
+			build()
+			c := internalaction.NewOperatorGroupCreate(&cfg)
+			c.TargetNamespaces = []string{"etcd-namespace"}
+			c.UpgradeStrategy = "Fast"
+			_, err := c.Run(context.TODO())
+			Expect(err).To(MatchError(ContainSubstring(`invalid upgrade strategy "Fast"`)))
+		})
+	})
+
+	Context("set-targets", func() {
+		BeforeEach(func() {
+			objs = append(objs, &v1.OperatorGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "etcd-namespace", Namespace: "etcd-namespace"},
+				Spec:       v1.OperatorGroupSpec{TargetNamespaces: []string{"etcd-namespace"}},
+			})
+		})
+
+		It("should change targets supported by installed operators", func() {
+			build()
+			s := internalaction.NewOperatorGroupSetTargets(&cfg)
+			s.Name = "etcd-namespace"
+			s.TargetNamespaces = []string{"team-a"}
+			_, err := s.Run(context.TODO())
+			Expect(err).To(BeNil())
+			Expect(get("etcd-namespace").Spec.TargetNamespaces).To(Equal([]string{"team-a"}))
+		})
+
+		It("should resolve selectors before checking install modes", func() {
+			build()
+			s := internalaction.NewOperatorGroupSetTargets(&cfg)
+			s.Name = "etcd-namespace"
+			s.Selector = "team=a"
+			_, err := s.Run(context.TODO())
+			Expect(err).To(MatchError(ContainSubstring(`install modes "MultiNamespace" are not supported`)))
+			Expect(get("etcd-namespace").Spec.TargetNamespaces).To(Equal([]string{"etcd-namespace"}))
+		})
+
+		It("should refuse a selector that matches no namespaces", func() {
+			build()
+			s := internalaction.NewOperatorGroupSetTargets(&cfg)
+			s.Name = "etcd-namespace"
+			s.Selector = "team=z"
+			_, err := s.Run(context.TODO())
+			Expect(err).To(MatchError(ContainSubstring(`selector "team=z" matches no namespaces`)))
+			Expect(get("etcd-namespace").Spec.Selector).To(BeNil())
+		})
+
+		It("should change unsupported targets with force", func() {
+			build()
+			s := internalaction.NewOperatorGroupSetTargets(&cfg)
+			s.Name = "etcd-namespace"
+			s.Force = true
+			_, err := s.Run(context.TODO())
+			Expect(err).To(BeNil())
+			Expect(get("etcd-namespace").Spec.TargetNamespaces).To(BeEmpty())
+		})
+	})
+
+	Context("delete", func() {
+		BeforeEach(func() {
+			objs = append(objs,
+				&v1.OperatorGroup{ObjectMeta: metav1.ObjectMeta{Name: "etcd-namespace", Namespace: "etcd-namespace"}},
+				&v1alpha1.Subscription{ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "etcd-namespace"}},
+			)
+		})
+
+		It("should not delete an operator group with subscriptions", func() {
+			build()
+			d := internalaction.NewOperatorGroupDelete(&cfg)
+			d.Name = "etcd-namespace"
+			Expect(d.Run(context.TODO())).To(MatchError(ContainSubstring("still has 1 subscription(s)")))
+		})
+
+		It("should delete an operator group with subscriptions with force", func() {
+			build()
+			d := internalaction.NewOperatorGroupDelete(&cfg)
+			d.Name = "etcd-namespace"
+			d.Force = true
+			Expect(d.Run(context.TODO())).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,175 @@
+// Package cluster holds the reads and writes of OLM objects that are shared by
+// the public actions in pkg/action and the internal ones.
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/operator"
+	"github.com/operator-framework/kubectl-operator/internal/pkg/subscription"
+)
+
+// FieldManager is the field manager that kubectl-operator applies objects as.
+const FieldManager = "kubectl-operator"
+
+// Apply creates or updates obj with server-side apply as the
+// kubectl-operator field manager, so that re-running a command converges on
+// the same object. Fields owned by other managers are not taken over; the
+// conflict is returned as an error naming them.
+func Apply(ctx context.Context, cl client.Client, scheme *runtime.Scheme, obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	obj.SetManagedFields(nil)
+	obj.SetResourceVersion("")
+
+	if err := cl.Patch(ctx, obj, client.Apply, client.FieldOwner(FieldManager)); err != nil {
+		if apierrors.IsConflict(err) {
+			return fmt.Errorf("%s %q has fields managed by another field manager: %w", strings.ToLower(gvk.Kind), obj.GetName(), err)
+		}
+		return err
+	}
+	return nil
+}
+
+// WaitForDeletion waits until each of objs is gone.
+func WaitForDeletion(ctx context.Context, cl client.Client, objs ...client.Object) error {
+	for _, obj := range objs {
+		obj := obj
+		lowerKind := strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind)
+		key := client.ObjectKeyFromObject(obj)
+		if err := wait.PollUntilContextCancel(ctx, 250*time.Millisecond, true, func(conditionCtx context.Context) (bool, error) {
+			if err := cl.Get(conditionCtx, key, obj); apierrors.IsNotFound(err) {
+				return true, nil
+			} else if err != nil {
+				return false, err
+			}
+			return false, nil
+		}); err != nil {
+			return fmt.Errorf("wait for %s %q deleted: %w", lowerKind, key.Name, err)
+		}
+	}
+	return nil
+}
+
+// ApproveInstallPlan sets the install plan's approved flag, retrying on
+// conflicts with OLM's own updates.
+func ApproveInstallPlan(ctx context.Context, cl client.Client, ip *v1alpha1.InstallPlan) error {
+	ipKey := client.ObjectKeyFromObject(ip)
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := cl.Get(ctx, ipKey, ip); err != nil {
+			return err
+		}
+		ip.Spec.Approved = true
+		return cl.Update(ctx, ip)
+	})
+}
+
+// GetPackageManifest selects the package manifest for packageName in
+// namespace from the ones served by the catalogs matching catalog, whose
+// name and namespace may each be empty to match any. It is an error if more
+// than one catalog matches, since packageserver would otherwise pick one of
+// them.
+func GetPackageManifest(ctx context.Context, cl client.Client, namespace, packageName string, catalog types.NamespacedName) (*operator.PackageManifest, error) {
+	labelSelector := client.MatchingLabels{}
+	if catalog.Name != "" {
+		labelSelector["catalog"] = catalog.Name
+	}
+	if catalog.Namespace != "" {
+		labelSelector["catalog-namespace"] = catalog.Namespace
+	}
+	pms := operatorsv1.PackageManifestList{}
+	if err := cl.List(ctx, &pms, labelSelector, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	candidates := []operatorsv1.PackageManifest{}
+	for _, pm := range pms.Items {
+		if pm.Name == packageName {
+			candidates = append(candidates, pm)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		if catalog.Name != "" {
+			return nil, &operator.ErrPackageNotFound{PackageName: packageName, Catalog: catalog.String()}
+		}
+		return nil, &operator.ErrPackageNotFound{PackageName: packageName}
+	case 1:
+		return &operator.PackageManifest{PackageManifest: candidates[0]}, nil
+	default:
+		catalogs := make([]string, 0, len(candidates))
+		for _, pm := range candidates {
+			catalogs = append(catalogs, pm.Status.CatalogSourceNamespace+"/"+pm.Status.CatalogSource)
+		}
+		sort.Strings(catalogs)
+		return nil, fmt.Errorf("package %q is served by more than one catalog (%s); use --catalog to choose one", packageName, strings.Join(catalogs, ", "))
+	}
+}
+
+// CheckInstallPlanVersionConstraint refuses install plans that would install a
+// CSV outside the version constraint recorded on the subscription, if any.
+func CheckInstallPlanVersionConstraint(ctx context.Context, cl client.Client, sub *v1alpha1.Subscription, ip *v1alpha1.InstallPlan) error {
+	constraint, ok := sub.GetAnnotations()[subscription.VersionConstraintAnnotation]
+	if !ok {
+		return nil
+	}
+
+	versions, err := getEntryVersions(ctx, cl, sub)
+	if err != nil {
+		return err
+	}
+	for _, csvName := range ip.Spec.ClusterServiceVersionNames {
+		version, ok := versions[csvName]
+		if !ok {
+			// Fall back to the version embedded in the CSV name.
+			version = operator.SemverRegexp.FindString(csvName)
+		}
+		if version == "" {
+			return fmt.Errorf("install plan %q: cannot determine version of csv %q to check against constraint %q", ip.Name, csvName, constraint)
+		}
+		if err := operator.CheckVersionConstraint(constraint, version); err != nil {
+			return fmt.Errorf("refusing to approve install plan %q for csv %q: %w", ip.Name, csvName, err)
+		}
+	}
+	return nil
+}
+
+// getEntryVersions maps the CSV names in the subscription's channel to their
+// versions, as served by the subscription's catalog.
+func getEntryVersions(ctx context.Context, cl client.Client, sub *v1alpha1.Subscription) (map[string]string, error) {
+	catalog := types.NamespacedName{
+		Namespace: sub.Spec.CatalogSourceNamespace,
+		Name:      sub.Spec.CatalogSource,
+	}
+	pm, err := GetPackageManifest(ctx, cl, sub.Namespace, sub.Spec.Package, catalog)
+	if err != nil {
+		return nil, fmt.Errorf("get package manifest: %w", err)
+	}
+	pc, err := pm.GetChannel(sub.Spec.Channel)
+	if err != nil {
+		return nil, fmt.Errorf("get package channel: %w", err)
+	}
+	versions := map[string]string{}
+	for _, entry := range pc.Entries {
+		versions[entry.Name] = entry.Version
+	}
+	return versions, nil
+}
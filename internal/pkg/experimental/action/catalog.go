@@ -0,0 +1,98 @@
+package action
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// catalogd's API is not imported directly: this module only reaches catalogd
+// v0.17.0 indirectly, as a requirement of operator-controller v0.12.0. So
+// ClusterCatalogs are read and written as unstructured objects and converted
+// to the types below, which mirror the fields of catalogd v0.17.0's v1alpha1
+// API that this package uses. Check them against that API when
+// operator-controller is bumped.
+var clusterCatalogGVK = schema.GroupVersionKind{
+	Group:   "catalogd.operatorframework.io",
+	Version: "v1alpha1",
+	Kind:    "ClusterCatalog",
+}
+
+const (
+	catalogSourceTypeImage = "image"
+
+	typeUnpacked = "Unpacked"
+)
+
+type ClusterCatalog struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterCatalogSpec   `json:"spec"`
+	Status ClusterCatalogStatus `json:"status,omitempty"`
+}
+
+type ClusterCatalogSpec struct {
+	Source CatalogSource `json:"source"`
+}
+
+type CatalogSource struct {
+	Type  string       `json:"type"`
+	Image *ImageSource `json:"image,omitempty"`
+}
+
+type ImageSource struct {
+	Ref          string           `json:"ref"`
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+type ClusterCatalogStatus struct {
+	Conditions         []metav1.Condition     `json:"conditions,omitempty"`
+	ResolvedSource     *ResolvedCatalogSource `json:"resolvedSource,omitempty"`
+	ContentURL         string                 `json:"contentURL,omitempty"`
+	ObservedGeneration int64                  `json:"observedGeneration,omitempty"`
+}
+
+type ResolvedCatalogSource struct {
+	Type  string               `json:"type"`
+	Image *ResolvedImageSource `json:"image,omitempty"`
+}
+
+type ResolvedImageSource struct {
+	Ref          string      `json:"ref"`
+	ResolvedRef  string      `json:"resolvedRef,omitempty"`
+	LastUnpacked metav1.Time `json:"lastUnpacked,omitempty"`
+}
+
+// Unpacked returns the catalog's Unpacked condition, if catalogd has set it.
+func (c *ClusterCatalog) Unpacked() *metav1.Condition {
+	return meta.FindStatusCondition(c.Status.Conditions, typeUnpacked)
+}
+
+// ResolvedRef returns the digest reference of the catalog image that was last
+// unpacked, if any.
+func (c *ClusterCatalog) ResolvedRef() string {
+	if c.Status.ResolvedSource == nil || c.Status.ResolvedSource.Image == nil {
+		return ""
+	}
+	return c.Status.ResolvedSource.Image.ResolvedRef
+}
+
+func newUnstructuredClusterCatalog(name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(clusterCatalogGVK)
+	u.SetName(name)
+	return u
+}
+
+func clusterCatalogFromUnstructured(u *unstructured.Unstructured) (*ClusterCatalog, error) {
+	c := &ClusterCatalog{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, c); err != nil {
+		return nil, fmt.Errorf("convert clustercatalog %q: %v", u.GetName(), err)
+	}
+	return c, nil
+}
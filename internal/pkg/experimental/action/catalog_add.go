@@ -0,0 +1,94 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+type CatalogAdd struct {
+	config *action.Configuration
+
+	CatalogName string
+	ImageRef    string
+
+	// PollInterval is how often catalogd checks the image ref for a new
+	// digest. If zero, the image is only unpacked once.
+	PollInterval time.Duration
+
+	Logf func(string, ...interface{})
+}
+
+func NewCatalogAdd(cfg *action.Configuration) *CatalogAdd {
+	return &CatalogAdd{
+		config: cfg,
+		Logf:   func(string, ...interface{}) {},
+	}
+}
+
+func (a *CatalogAdd) Run(ctx context.Context) (*ClusterCatalog, error) {
+	if a.PollInterval < 0 {
+		return nil, fmt.Errorf("poll interval must not be negative")
+	}
+	image := map[string]interface{}{"ref": a.ImageRef}
+	if a.PollInterval > 0 {
+		image["pollInterval"] = a.PollInterval.String()
+	}
+
+	u := newUnstructuredClusterCatalog(a.CatalogName)
+	u.Object["spec"] = map[string]interface{}{
+		"source": map[string]interface{}{
+			"type":  catalogSourceTypeImage,
+			"image": image,
+		},
+	}
+	if err := a.config.Client.Create(ctx, u); err != nil {
+		return nil, fmt.Errorf("create clustercatalog %q: %v", a.CatalogName, err)
+	}
+	a.Logf("clustercatalog %q created", a.CatalogName)
+
+	if err := waitForUnpacked(ctx, a.config, u, a.Logf); err != nil {
+		return nil, err
+	}
+	return clusterCatalogFromUnstructured(u)
+}
+
+// waitForUnpacked waits until the catalog's Unpacked condition is true,
+// logging changes to the condition and including the last observed condition
+// in the error if the wait times out.
+func waitForUnpacked(ctx context.Context, cfg *action.Configuration, u *unstructured.Unstructured, logf func(string, ...interface{})) error {
+	key := types.NamespacedName{Name: u.GetName()}
+	var unpacked *metav1.Condition
+	reported := ""
+	if err := wait.PollUntilContextCancel(ctx, pollTimeout, true, func(conditionCtx context.Context) (bool, error) {
+		if err := cfg.Client.Get(conditionCtx, key, u); err != nil {
+			return false, err
+		}
+		cat, err := clusterCatalogFromUnstructured(u)
+		if err != nil {
+			return false, err
+		}
+		unpacked = cat.Unpacked()
+		if unpacked == nil {
+			return false, nil
+		}
+		if s := formatCondition(unpacked); s != reported {
+			reported = s
+			logf("%s", s)
+		}
+		return unpacked.Status == metav1.ConditionTrue, nil
+	}); err != nil {
+		if unpacked == nil {
+			return fmt.Errorf("waiting for catalog to be unpacked: %v: no conditions reported by catalogd", err)
+		}
+		return fmt.Errorf("waiting for catalog to be unpacked: %v: %s", err, formatCondition(unpacked))
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// AvailablePackage is a package served by a catalog, with the versions of
+// the bundles in each of its channels.
+type AvailablePackage struct {
+	Catalog        string
+	Name           string
+	DefaultChannel string
+	Channels       []AvailableChannel
+}
+
+type AvailableChannel struct {
+	Name string
+
+	// Versions are sorted from lowest to highest.
+	Versions []string
+}
+
+// Channel returns the named channel, or nil if the package has no such
+// channel.
+func (p *AvailablePackage) Channel(name string) *AvailableChannel {
+	for i := range p.Channels {
+		if p.Channels[i].Name == name {
+			return &p.Channels[i]
+		}
+	}
+	return nil
+}
+
+// checkAvailable returns an error if the package has no bundle in the
+// channel, or in any channel if channel is empty, whose version satisfies
+// the version range.
+func (p *AvailablePackage) checkAvailable(channel, versionRange string) error {
+	channels := p.Channels
+	if channel != "" {
+		c := p.Channel(channel)
+		if c == nil {
+			return fmt.Errorf("package %q in catalog %q has no channel %q", p.Name, p.Catalog, channel)
+		}
+		channels = []AvailableChannel{*c}
+	}
+	if versionRange == "" {
+		return nil
+	}
+	constraint, err := semver.NewConstraint(versionRange)
+	if err != nil {
+		return fmt.Errorf("parse version range %q: %v", versionRange, err)
+	}
+	for _, c := range channels {
+		for _, version := range c.Versions {
+			if v, err := semver.NewVersion(version); err == nil && constraint.Check(v) {
+				return nil
+			}
+		}
+	}
+	if channel != "" {
+		return fmt.Errorf("no version of package %q in channel %q of catalog %q satisfies %q", p.Name, channel, p.Catalog, versionRange)
+	}
+	return fmt.Errorf("no version of package %q in catalog %q satisfies %q", p.Name, p.Catalog, versionRange)
+}
+
+// catalogPackages reads the file-based catalog content that catalogd serves
+// for the catalog and returns the packages for which match returns true.
+func catalogPackages(ctx context.Context, cfg *action.Configuration, cat *ClusterCatalog, match func(pkg string) bool) ([]AvailablePackage, error) {
+	rc, err := openCatalogContent(ctx, cfg, cat)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var (
+		pkgs     = map[string]*AvailablePackage{}
+		entries  = map[string]map[string][]string{}
+		versions = map[string]map[string]string{}
+	)
+	if err := declcfg.WalkMetasReader(rc, func(meta *declcfg.Meta, err error) error {
+		if err != nil {
+			return err
+		}
+		pkg := meta.Package
+		if meta.Schema == declcfg.SchemaPackage {
+			pkg = meta.Name
+		}
+		if !match(pkg) {
+			return nil
+		}
+		switch meta.Schema {
+		case declcfg.SchemaPackage:
+			p := declcfg.Package{}
+			if err := json.Unmarshal(meta.Blob, &p); err != nil {
+				return fmt.Errorf("parse package %q: %v", meta.Name, err)
+			}
+			pkgs[p.Name] = &AvailablePackage{Catalog: cat.Name, Name: p.Name, DefaultChannel: p.DefaultChannel}
+		case declcfg.SchemaChannel:
+			c := declcfg.Channel{}
+			if err := json.Unmarshal(meta.Blob, &c); err != nil {
+				return fmt.Errorf("parse channel %q: %v", meta.Name, err)
+			}
+			if entries[c.Package] == nil {
+				entries[c.Package] = map[string][]string{}
+			}
+			for _, e := range c.Entries {
+				entries[c.Package][c.Name] = append(entries[c.Package][c.Name], e.Name)
+			}
+		case declcfg.SchemaBundle:
+			b := declcfg.Bundle{}
+			if err := json.Unmarshal(meta.Blob, &b); err != nil {
+				return fmt.Errorf("parse bundle %q: %v", meta.Name, err)
+			}
+			props, err := property.Parse(b.Properties)
+			if err != nil {
+				return fmt.Errorf("parse properties of bundle %q: %v", b.Name, err)
+			}
+			if len(props.Packages) == 0 {
+				return nil
+			}
+			if versions[b.Package] == nil {
+				versions[b.Package] = map[string]string{}
+			}
+			versions[b.Package][b.Name] = props.Packages[0].Version
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("read content of catalog %q: %v", cat.Name, err)
+	}
+
+	result := make([]AvailablePackage, 0, len(pkgs))
+	for name, p := range pkgs {
+		for channel, bundles := range entries[name] {
+			c := AvailableChannel{Name: channel}
+			for _, b := range bundles {
+				if v, ok := versions[name][b]; ok {
+					c.Versions = append(c.Versions, v)
+				}
+			}
+			sortVersions(c.Versions)
+			p.Channels = append(p.Channels, c)
+		}
+		sort.Slice(p.Channels, func(i, j int) bool {
+			return p.Channels[i].Name < p.Channels[j].Name
+		})
+		result = append(result, *p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+func sortVersions(versions []string) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		vi, erri := semver.NewVersion(versions[i])
+		vj, errj := semver.NewVersion(versions[j])
+		if erri != nil || errj != nil {
+			return versions[i] < versions[j]
+		}
+		return vi.LessThan(vj)
+	})
+}
+
+// openCatalogContent returns the catalog's content stream. catalogd serves
+// content from an in-cluster service, which is reached through the API
+// server's service proxy. Content URLs outside the cluster are fetched
+// directly.
+func openCatalogContent(ctx context.Context, cfg *action.Configuration, cat *ClusterCatalog) (io.ReadCloser, error) {
+	if cat.Status.ContentURL == "" {
+		return nil, fmt.Errorf("catalog %q has no content; it may not be unpacked yet", cat.Name)
+	}
+	u, err := url.Parse(cat.Status.ContentURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse content URL of catalog %q: %v", cat.Name, err)
+	}
+
+	service, namespace, ok := clusterService(u.Hostname())
+	if !ok {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("get content of catalog %q: %v", cat.Name, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("get content of catalog %q: unexpected status %s", cat.Name, resp.Status)
+		}
+		return resp.Body, nil
+	}
+
+	if cfg.RESTConfig == nil {
+		return nil, fmt.Errorf("get content of catalog %q: no cluster connection to proxy service %s/%s", cat.Name, namespace, service)
+	}
+	cs, err := kubernetes.NewForConfig(cfg.RESTConfig)
+	if err != nil {
+		return nil, err
+	}
+	port := u.Port()
+	if port == "" {
+		port = "443"
+		if u.Scheme == "http" {
+			port = "80"
+		}
+	}
+	rc, err := cs.CoreV1().Services(namespace).ProxyGet(u.Scheme, service, port, u.Path, nil).Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get content of catalog %q through service %s/%s: %v", cat.Name, namespace, service, err)
+	}
+	return rc, nil
+}
+
+// clusterService returns the service name and namespace of a cluster-local
+// host name such as "catalogd-service.olmv1-system.svc".
+func clusterService(host string) (string, string, bool) {
+	if net.ParseIP(host) != nil {
+		return "", "", false
+	}
+	labels := strings.Split(host, ".")
+	if len(labels) < 3 || labels[2] != "svc" {
+		return "", "", false
+	}
+	return labels[0], labels[1], true
+}
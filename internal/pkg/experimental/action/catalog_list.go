@@ -0,0 +1,40 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+type CatalogList struct {
+	config *action.Configuration
+}
+
+func NewCatalogList(cfg *action.Configuration) *CatalogList {
+	return &CatalogList{cfg}
+}
+
+func (l *CatalogList) Run(ctx context.Context) ([]ClusterCatalog, error) {
+	ul := unstructured.UnstructuredList{}
+	ul.SetGroupVersionKind(clusterCatalogGVK.GroupVersion().WithKind(clusterCatalogGVK.Kind + "List"))
+	if err := l.config.Client.List(ctx, &ul); err != nil {
+		return nil, fmt.Errorf("list clustercatalogs: %v", err)
+	}
+
+	catalogs := make([]ClusterCatalog, 0, len(ul.Items))
+	for i := range ul.Items {
+		c, err := clusterCatalogFromUnstructured(&ul.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		catalogs = append(catalogs, *c)
+	}
+	sort.Slice(catalogs, func(i, j int) bool {
+		return catalogs[i].Name < catalogs[j].Name
+	})
+	return catalogs, nil
+}
@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/operator-framework/api/pkg/operators/v1alpha1"
-
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
@@ -22,11 +20,9 @@ func NewCatalogRemove(cfg *action.Configuration) *CatalogRemove {
 }
 
 func (r *CatalogRemove) Run(ctx context.Context) error {
-	cs := v1alpha1.CatalogSource{}
-	cs.SetNamespace(r.config.Namespace)
-	cs.SetName(r.CatalogName)
-	if err := r.config.Client.Delete(ctx, &cs); err != nil {
-		return fmt.Errorf("delete catalogsource %q: %v", cs.Name, err)
+	u := newUnstructuredClusterCatalog(r.CatalogName)
+	if err := r.config.Client.Delete(ctx, u); err != nil {
+		return fmt.Errorf("delete clustercatalog %q: %v", r.CatalogName, err)
 	}
-	return waitForDeletion(ctx, r.config.Client, &cs)
+	return waitForDeletion(ctx, r.config.Client, u)
 }
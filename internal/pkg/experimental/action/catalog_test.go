@@ -0,0 +1,149 @@
+package action_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	experimentalaction "github.com/operator-framework/kubectl-operator/internal/pkg/experimental/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var clusterCatalogGVK = schema.GroupVersionKind{Group: "catalogd.operatorframework.io", Version: "v1alpha1", Kind: "ClusterCatalog"}
+
+func newClusterCatalog(name, ref string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(clusterCatalogGVK)
+	u.SetName(name)
+	u.Object["spec"] = map[string]interface{}{
+		"source": map[string]interface{}{
+			"type":  "image",
+			"image": map[string]interface{}{"ref": ref},
+		},
+	}
+	return u
+}
+
+// setUnpacked sets the status catalogd would report for the catalog.
+func setUnpacked(u *unstructured.Unstructured, status, reason string) {
+	ref, _, _ := unstructured.NestedString(u.Object, "spec", "source", "image", "ref")
+	u.Object["status"] = map[string]interface{}{
+		"conditions": []interface{}{map[string]interface{}{
+			"type":               "Unpacked",
+			"status":             status,
+			"reason":             reason,
+			"message":            fmt.Sprintf("unpack of %s: %s", ref, reason),
+			"lastTransitionTime": "2024-01-01T00:00:00Z",
+		}},
+		"resolvedSource": map[string]interface{}{
+			"type":  "image",
+			"image": map[string]interface{}{"ref": ref, "resolvedRef": ref + "@sha256:abc"},
+		},
+	}
+}
+
+var _ = Describe("Catalog", func() {
+	var (
+		cfg    action.Configuration
+		objs   []client.Object
+		status string
+		reason string
+	)
+
+	BeforeEach(func() {
+		objs = nil
+		status, reason = "True", "UnpackSuccessful"
+	})
+
+	build := func() {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+		cfg.Scheme = sch
+		cfg.Client = fake.NewClientBuilder().WithScheme(sch).WithObjects(objs...).WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if u, ok := obj.(*unstructured.Unstructured); ok {
+					setUnpacked(u, status, reason)
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).Build()
+	}
+
+	Describe("CatalogAdd", func() {
+		It("should create a catalog and wait for it to be unpacked", func() {
+			build()
+			a := experimentalaction.NewCatalogAdd(&cfg)
+			a.CatalogName = "operatorhubio"
+			a.ImageRef = "quay.io/operatorhubio/catalog:latest"
+			a.PollInterval = 10 * time.Minute
+			cat, err := a.Run(context.TODO())
+			Expect(err).To(BeNil())
+			Expect(cat.Spec.Source.Image.Ref).To(Equal("quay.io/operatorhubio/catalog:latest"))
+			Expect(cat.Spec.Source.Image.PollInterval.Duration).To(Equal(10 * time.Minute))
+			Expect(cat.ResolvedRef()).To(Equal("quay.io/operatorhubio/catalog:latest@sha256:abc"))
+		})
+
+		It("should report why the catalog is not unpacked", func() {
+			status, reason = "False", "UnpackFailed"
+			build()
+			a := experimentalaction.NewCatalogAdd(&cfg)
+			a.CatalogName = "broken"
+			a.ImageRef = "quay.io/example/missing:latest"
+			ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+			defer cancel()
+			_, err := a.Run(ctx)
+			Expect(err).To(MatchError(ContainSubstring("Unpacked=False (UnpackFailed): unpack of quay.io/example/missing:latest: UnpackFailed")))
+		})
+	})
+
+	Describe("CatalogList", func() {
+		It("should list catalogs with their unpack status", func() {
+			second := newClusterCatalog("second", "quay.io/example/second:latest")
+			first := newClusterCatalog("first", "quay.io/example/first:latest")
+			setUnpacked(first, "True", "UnpackSuccessful")
+			objs = []client.Object{second, first}
+			build()
+
+			cats, err := experimentalaction.NewCatalogList(&cfg).Run(context.TODO())
+			Expect(err).To(BeNil())
+			Expect(cats).To(HaveLen(2))
+			Expect(cats[0].Name).To(Equal("first"))
+			Expect(cats[0].Unpacked().Reason).To(Equal("UnpackSuccessful"))
+			Expect(cats[0].ResolvedRef()).To(Equal("quay.io/example/first:latest@sha256:abc"))
+			Expect(cats[1].Name).To(Equal("second"))
+			Expect(cats[1].Unpacked()).To(BeNil())
+			Expect(cats[1].ResolvedRef()).To(BeEmpty())
+		})
+	})
+
+	Describe("CatalogRemove", func() {
+		It("should delete the catalog", func() {
+			objs = []client.Object{newClusterCatalog("operatorhubio", "quay.io/operatorhubio/catalog:latest")}
+			build()
+
+			r := experimentalaction.NewCatalogRemove(&cfg)
+			r.CatalogName = "operatorhubio"
+			Expect(r.Run(context.TODO())).To(Succeed())
+
+			err := cfg.Client.Get(context.TODO(), client.ObjectKey{Name: "operatorhubio"}, newClusterCatalog("", ""))
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should fail for a missing catalog", func() {
+			build()
+			r := experimentalaction.NewCatalogRemove(&cfg)
+			r.CatalogName = "missing"
+			Expect(r.Run(context.TODO())).To(MatchError(ContainSubstring(`delete clustercatalog "missing"`)))
+		})
+	})
+})
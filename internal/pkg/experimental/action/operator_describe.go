@@ -0,0 +1,36 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	olmv1 "github.com/operator-framework/operator-controller/api/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+type OperatorDescribe struct {
+	config *action.Configuration
+
+	Name string
+}
+
+func NewOperatorDescribe(cfg *action.Configuration) *OperatorDescribe {
+	return &OperatorDescribe{
+		config: cfg,
+	}
+}
+
+func (d *OperatorDescribe) Run(ctx context.Context) (*olmv1.ClusterExtension, error) {
+	return getClusterExtension(ctx, d.config, d.Name)
+}
+
+func getClusterExtension(ctx context.Context, cfg *action.Configuration, name string) (*olmv1.ClusterExtension, error) {
+	ext := &olmv1.ClusterExtension{}
+	if err := cfg.Client.Get(ctx, types.NamespacedName{Name: name}, ext); err != nil {
+		return nil, fmt.Errorf("get clusterextension %q: %v", name, err)
+	}
+	return ext, nil
+}
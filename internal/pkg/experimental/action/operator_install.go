@@ -2,7 +2,9 @@ package action
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,6 +21,16 @@ type OperatorInstall struct {
 
 	Package string
 
+	// Version is a semver range, such as ">=1.2.0 <2.0.0". If empty, the
+	// latest version of the package is installed.
+	Version string
+	Channel string
+
+	// InstallNamespace defaults to the configured namespace.
+	InstallNamespace        string
+	ServiceAccount          string
+	UpgradeConstraintPolicy string
+
 	Logf func(string, ...interface{})
 }
 
@@ -30,39 +42,120 @@ func NewOperatorInstall(cfg *action.Configuration) *OperatorInstall {
 }
 
 func (i *OperatorInstall) Run(ctx context.Context) (*olmv1.ClusterExtension, error) {
-	// TODO(developer): Lookup package information when the OLMv1 equivalent of the
-	//     packagemanifests API is available. That way, we can check to see if the
-	//     package is actually available to the cluster before creating the Operator
-	//     object.
+	if i.InstallNamespace == "" {
+		i.InstallNamespace = i.config.Namespace
+	}
+	if i.ServiceAccount == "" {
+		return nil, fmt.Errorf("a service account in namespace %q is required to install the operator", i.InstallNamespace)
+	}
+	policy, err := parseUpgradeConstraintPolicy(i.UpgradeConstraintPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.checkPackageAvailable(ctx); err != nil {
+		return nil, err
+	}
 
 	opKey := types.NamespacedName{Name: i.Package}
 	op := &olmv1.ClusterExtension{
 		ObjectMeta: metav1.ObjectMeta{Name: opKey.Name},
-		Spec:       olmv1.ClusterExtensionSpec{PackageName: i.Package},
+		Spec: olmv1.ClusterExtensionSpec{
+			PackageName:             i.Package,
+			Version:                 i.Version,
+			Channel:                 i.Channel,
+			UpgradeConstraintPolicy: policy,
+			InstallNamespace:        i.InstallNamespace,
+			ServiceAccount:          olmv1.ServiceAccountReference{Name: i.ServiceAccount},
+		},
 	}
 	if err := i.config.Client.Create(ctx, op); err != nil {
 		return nil, err
 	}
 
-	// TODO(developer): Improve the logic in this poll wait once the Operator reconciler
-	//     and conditions types and reasons are improved. For now, this will stop waiting as
-	//     soon as a Ready condition is found, but we should probably wait until the Operator
-	//     stops progressing.
-	// All Types will exist, so Ready may have a false Status. So, wait until
-	// Type=Ready,Status=True happens
+	if err := waitForInstalled(ctx, i.config, op, i.Logf); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// checkPackageAvailable returns an error unless a catalog serves the package
+// with a bundle in the requested channel and version range.
+func (i *OperatorInstall) checkPackageAvailable(ctx context.Context) error {
+	l := NewOperatorListAvailable(i.config)
+	l.Package = i.Package
+	l.Logf = i.Logf
+	pkgs, err := l.Run(ctx)
+	if err != nil {
+		return fmt.Errorf("look up package %q: %v", i.Package, err)
+	}
+	if len(pkgs) == 0 {
+		return fmt.Errorf("package %q not found in any catalog", i.Package)
+	}
+
+	errs := []string{}
+	for _, p := range pkgs {
+		p := p
+		err := p.checkAvailable(i.Channel, i.Version)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err.Error())
+	}
+	return errors.New(strings.Join(errs, "; "))
+}
+
+func parseUpgradeConstraintPolicy(s string) (olmv1.UpgradeConstraintPolicy, error) {
+	switch policy := olmv1.UpgradeConstraintPolicy(s); policy {
+	case "", olmv1.UpgradeConstraintPolicyEnforce, olmv1.UpgradeConstraintPolicyIgnore:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid upgrade constraint policy %q, expected one of %s|%s", s, olmv1.UpgradeConstraintPolicyEnforce, olmv1.UpgradeConstraintPolicyIgnore)
+	}
+}
 
+// waitForInstalled waits until the cluster extension's Installed condition is
+// true for its current generation. Changes to the Resolved and Installed
+// conditions are logged as they happen, and the last observed conditions are
+// included in the error if the wait times out.
+func waitForInstalled(ctx context.Context, cfg *action.Configuration, op *olmv1.ClusterExtension, logf func(string, ...interface{})) error {
+	key := types.NamespacedName{Name: op.Name}
+	reported := map[string]string{}
 	if err := wait.PollUntilContextCancel(ctx, pollTimeout, true, func(conditionCtx context.Context) (bool, error) {
-		if err := i.config.Client.Get(conditionCtx, opKey, op); err != nil {
+		if err := cfg.Client.Get(conditionCtx, key, op); err != nil {
 			return false, err
 		}
-		installedCondition := meta.FindStatusCondition(op.Status.Conditions, olmv1.TypeInstalled)
-		if installedCondition != nil && installedCondition.Status == metav1.ConditionTrue {
-			return true, nil
+		for _, t := range []string{olmv1.TypeResolved, olmv1.TypeInstalled} {
+			c := meta.FindStatusCondition(op.Status.Conditions, t)
+			if c == nil || c.ObservedGeneration != op.Generation {
+				continue
+			}
+			if s := formatCondition(c); reported[t] != s {
+				reported[t] = s
+				logf("%s", s)
+			}
 		}
-		return false, nil
+		installed := meta.FindStatusCondition(op.Status.Conditions, olmv1.TypeInstalled)
+		return installed != nil && installed.ObservedGeneration == op.Generation && installed.Status == metav1.ConditionTrue, nil
 	}); err != nil {
-		return nil, fmt.Errorf("waiting for operator to become ready: %v", err)
+		last := []string{}
+		for _, t := range []string{olmv1.TypeResolved, olmv1.TypeInstalled} {
+			if c := meta.FindStatusCondition(op.Status.Conditions, t); c != nil {
+				last = append(last, formatCondition(c))
+			}
+		}
+		if len(last) == 0 {
+			return fmt.Errorf("waiting for operator to be installed: %v: no conditions reported by operator-controller", err)
+		}
+		return fmt.Errorf("waiting for operator to be installed: %v: %s", err, strings.Join(last, "; "))
 	}
+	return nil
+}
 
-	return op, nil
+func formatCondition(c *metav1.Condition) string {
+	s := fmt.Sprintf("%s=%s (%s)", c.Type, c.Status, c.Reason)
+	if c.Message != "" {
+		s += ": " + c.Message
+	}
+	return s
 }
@@ -0,0 +1,145 @@
+package action_test
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	olmv1 "github.com/operator-framework/operator-controller/api/v1alpha1"
+
+	experimentalaction "github.com/operator-framework/kubectl-operator/internal/pkg/experimental/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("OperatorInstall", func() {
+	var (
+		cfg        action.Configuration
+		srv        *httptest.Server
+		conditions []metav1.Condition
+		logs       []string
+	)
+
+	BeforeEach(func() {
+		conditions = nil
+		logs = nil
+
+		var cat *unstructured.Unstructured
+		srv, cat = newCatalogServer("operatorhubio", operatorhubioFBC)
+
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+		cfg.Scheme = sch
+		cfg.Namespace = "etcd-namespace"
+		// The fake client has no operator-controller, so set the conditions
+		// it would report as the cluster extension is created.
+		cfg.Client = fake.NewClientBuilder().WithScheme(sch).WithObjects(cat).WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if ext, ok := obj.(*olmv1.ClusterExtension); ok {
+					for _, cond := range conditions {
+						meta.SetStatusCondition(&ext.Status.Conditions, cond)
+					}
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).Build()
+	})
+
+	AfterEach(func() {
+		srv.Close()
+	})
+
+	newInstall := func() *experimentalaction.OperatorInstall {
+		i := experimentalaction.NewOperatorInstall(&cfg)
+		i.Package = "etcd"
+		i.ServiceAccount = "etcd-installer"
+		i.Logf = func(format string, args ...interface{}) {
+			logs = append(logs, fmt.Sprintf(format, args...))
+		}
+		return i
+	}
+
+	It("should require a service account", func() {
+		i := newInstall()
+		i.ServiceAccount = ""
+		_, err := i.Run(context.TODO())
+		Expect(err).To(MatchError(`a service account in namespace "etcd-namespace" is required to install the operator`))
+	})
+
+	It("should reject an unknown upgrade constraint policy", func() {
+		i := newInstall()
+		i.UpgradeConstraintPolicy = "Sometimes"
+		_, err := i.Run(context.TODO())
+		Expect(err).To(MatchError(ContainSubstring(`invalid upgrade constraint policy "Sometimes"`)))
+	})
+
+	It("should refuse a package that no catalog serves", func() {
+		i := newInstall()
+		i.Package = "prometheus"
+		_, err := i.Run(context.TODO())
+		Expect(err).To(MatchError(`package "prometheus" not found in any catalog`))
+	})
+
+	It("should refuse a channel or version the package does not have", func() {
+		i := newInstall()
+		i.Channel = "stable"
+		_, err := i.Run(context.TODO())
+		Expect(err).To(MatchError(`package "etcd" in catalog "operatorhubio" has no channel "stable"`))
+
+		i = newInstall()
+		i.Channel = "singlenamespace-alpha"
+		i.Version = ">=1.0.0"
+		_, err = i.Run(context.TODO())
+		Expect(err).To(MatchError(`no version of package "etcd" in channel "singlenamespace-alpha" of catalog "operatorhubio" satisfies ">=1.0.0"`))
+
+		exts := olmv1.ClusterExtensionList{}
+		Expect(cfg.Client.List(context.TODO(), &exts)).To(Succeed())
+		Expect(exts.Items).To(BeEmpty())
+	})
+
+	It("should create the cluster extension with the install options", func() {
+		conditions = []metav1.Condition{
+			{Type: olmv1.TypeResolved, Status: metav1.ConditionTrue, Reason: olmv1.ReasonSuccess, Message: "resolved to etcdoperator.v0.9.4"},
+			{Type: olmv1.TypeInstalled, Status: metav1.ConditionTrue, Reason: olmv1.ReasonSuccess, Message: "installed etcdoperator.v0.9.4"},
+		}
+		i := newInstall()
+		i.Version = ">=0.9.0 <1.0.0"
+		i.Channel = "singlenamespace-alpha"
+		i.UpgradeConstraintPolicy = "Ignore"
+		ext, err := i.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(ext.Spec).To(Equal(olmv1.ClusterExtensionSpec{
+			PackageName:             "etcd",
+			Version:                 ">=0.9.0 <1.0.0",
+			Channel:                 "singlenamespace-alpha",
+			UpgradeConstraintPolicy: olmv1.UpgradeConstraintPolicyIgnore,
+			InstallNamespace:        "etcd-namespace",
+			ServiceAccount:          olmv1.ServiceAccountReference{Name: "etcd-installer"},
+		}))
+		Expect(logs).To(Equal([]string{
+			"Resolved=True (Success): resolved to etcdoperator.v0.9.4",
+			"Installed=True (Success): installed etcdoperator.v0.9.4",
+		}))
+	})
+
+	It("should report the conditions when the install does not finish", func() {
+		conditions = []metav1.Condition{
+			{Type: olmv1.TypeResolved, Status: metav1.ConditionFalse, Reason: olmv1.ReasonResolutionFailed, Message: `no package "etcd" found`},
+		}
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+		_, err := newInstall().Run(ctx)
+		Expect(err).To(MatchError(ContainSubstring(`Resolved=False (ResolutionFailed): no package "etcd" found`)))
+		Expect(logs).To(HaveLen(1))
+	})
+})
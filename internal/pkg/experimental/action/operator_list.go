@@ -0,0 +1,30 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	olmv1 "github.com/operator-framework/operator-controller/api/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+type OperatorList struct {
+	config *action.Configuration
+}
+
+func NewOperatorList(cfg *action.Configuration) *OperatorList {
+	return &OperatorList{cfg}
+}
+
+func (l *OperatorList) Run(ctx context.Context) ([]olmv1.ClusterExtension, error) {
+	exts := olmv1.ClusterExtensionList{}
+	if err := l.config.Client.List(ctx, &exts); err != nil {
+		return nil, fmt.Errorf("list clusterextensions: %v", err)
+	}
+	sort.Slice(exts.Items, func(i, j int) bool {
+		return exts.Items[i].Name < exts.Items[j].Name
+	})
+	return exts.Items, nil
+}
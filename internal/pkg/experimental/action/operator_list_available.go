@@ -0,0 +1,75 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// OperatorListAvailable lists the packages served by the unpacked catalogs
+// in the cluster.
+type OperatorListAvailable struct {
+	config *action.Configuration
+
+	// Catalog limits the packages to those of the named catalog.
+	Catalog string
+
+	// Package limits the packages to the one with this exact name.
+	Package string
+
+	// Keyword limits the packages to those whose name contains it.
+	Keyword string
+
+	Logf func(string, ...interface{})
+}
+
+func NewOperatorListAvailable(cfg *action.Configuration) *OperatorListAvailable {
+	return &OperatorListAvailable{
+		config: cfg,
+		Logf:   func(string, ...interface{}) {},
+	}
+}
+
+func (l *OperatorListAvailable) Run(ctx context.Context) ([]AvailablePackage, error) {
+	catalogs, err := NewCatalogList(l.config).Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	match := func(pkg string) bool {
+		if l.Package != "" && pkg != l.Package {
+			return false
+		}
+		return strings.Contains(pkg, l.Keyword)
+	}
+
+	found := false
+	var pkgs []AvailablePackage
+	for i := range catalogs {
+		cat := &catalogs[i]
+		if l.Catalog != "" && cat.Name != l.Catalog {
+			continue
+		}
+		found = true
+		if cat.Status.ContentURL == "" && l.Catalog == "" {
+			l.Logf("skipping catalog %q: it has not been unpacked", cat.Name)
+			continue
+		}
+		catPkgs, err := catalogPackages(ctx, l.config, cat, match)
+		if err != nil {
+			return nil, err
+		}
+		pkgs = append(pkgs, catPkgs...)
+	}
+	if l.Catalog != "" && !found {
+		return nil, fmt.Errorf("catalog %q not found", l.Catalog)
+	}
+
+	sort.SliceStable(pkgs, func(i, j int) bool {
+		return pkgs[i].Name < pkgs[j].Name
+	})
+	return pkgs, nil
+}
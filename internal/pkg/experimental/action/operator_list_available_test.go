@@ -0,0 +1,114 @@
+package action_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	experimentalaction "github.com/operator-framework/kubectl-operator/internal/pkg/experimental/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// operatorhubioFBC is the JSON stream catalogd serves for a catalog.
+const operatorhubioFBC = `{"schema":"olm.package","name":"etcd","defaultChannel":"singlenamespace-alpha"}
+{"schema":"olm.channel","package":"etcd","name":"singlenamespace-alpha","entries":[{"name":"etcdoperator.v0.9.4"},{"name":"etcdoperator.v0.9.2","skipRange":"<0.9.2"}]}
+{"schema":"olm.channel","package":"etcd","name":"clusterwide-alpha","entries":[{"name":"etcdoperator.v0.9.4-clusterwide"}]}
+{"schema":"olm.bundle","package":"etcd","name":"etcdoperator.v0.9.2","image":"quay.io/example/etcd:v0.9.2","properties":[{"type":"olm.package","value":{"packageName":"etcd","version":"0.9.2"}}]}
+{"schema":"olm.bundle","package":"etcd","name":"etcdoperator.v0.9.4","image":"quay.io/example/etcd:v0.9.4","properties":[{"type":"olm.package","value":{"packageName":"etcd","version":"0.9.4"}}]}
+{"schema":"olm.bundle","package":"etcd","name":"etcdoperator.v0.9.4-clusterwide","image":"quay.io/example/etcd:v0.9.4-clusterwide","properties":[{"type":"olm.package","value":{"packageName":"etcd","version":"0.9.4-clusterwide"}}]}
+{"schema":"olm.package","name":"etcd-backup","defaultChannel":"stable"}
+{"schema":"olm.channel","package":"etcd-backup","name":"stable","entries":[{"name":"etcd-backup.v1.0.0"}]}
+{"schema":"olm.bundle","package":"etcd-backup","name":"etcd-backup.v1.0.0","image":"quay.io/example/etcd-backup:v1.0.0","properties":[{"type":"olm.package","value":{"packageName":"etcd-backup","version":"1.0.0"}}]}
+{"schema":"olm.package","name":"argocd","defaultChannel":"alpha"}
+{"schema":"olm.channel","package":"argocd","name":"alpha","entries":[{"name":"argocd-operator.v0.6.0"}]}
+{"schema":"olm.bundle","package":"argocd","name":"argocd-operator.v0.6.0","image":"quay.io/example/argocd:v0.6.0","properties":[{"type":"olm.package","value":{"packageName":"argocd","version":"0.6.0"}}]}
+`
+
+// newCatalogServer starts a stand-in for catalogd that serves fbc for every
+// catalog, and returns an unpacked ClusterCatalog whose content URL points
+// at it.
+func newCatalogServer(name, fbc string) (*httptest.Server, *unstructured.Unstructured) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/catalogs/"+name+"/all.json" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(fbc))
+	}))
+	cat := newClusterCatalog(name, "quay.io/operatorhubio/catalog:latest")
+	setUnpacked(cat, "True", "UnpackSuccessful")
+	cat.Object["status"].(map[string]interface{})["contentURL"] = srv.URL + "/catalogs/" + name + "/all.json"
+	return srv, cat
+}
+
+var _ = Describe("OperatorListAvailable", func() {
+	var (
+		cfg action.Configuration
+		srv *httptest.Server
+	)
+
+	BeforeEach(func() {
+		var cat *unstructured.Unstructured
+		srv, cat = newCatalogServer("operatorhubio", operatorhubioFBC)
+
+		// catalogs that are not unpacked yet have no content to read
+		pending := newClusterCatalog("pending", "quay.io/example/pending:latest")
+
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+		cfg.Scheme = sch
+		cfg.Client = fake.NewClientBuilder().WithScheme(sch).WithObjects([]client.Object{cat, pending}...).Build()
+	})
+
+	AfterEach(func() {
+		srv.Close()
+	})
+
+	It("should list the packages, channels and versions of every catalog", func() {
+		pkgs, err := experimentalaction.NewOperatorListAvailable(&cfg).Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(pkgs).To(HaveLen(3))
+		Expect(pkgs[0].Name).To(Equal("argocd"))
+		Expect(pkgs[1]).To(Equal(experimentalaction.AvailablePackage{
+			Catalog:        "operatorhubio",
+			Name:           "etcd",
+			DefaultChannel: "singlenamespace-alpha",
+			Channels: []experimentalaction.AvailableChannel{
+				{Name: "clusterwide-alpha", Versions: []string{"0.9.4-clusterwide"}},
+				{Name: "singlenamespace-alpha", Versions: []string{"0.9.2", "0.9.4"}},
+			},
+		}))
+		Expect(pkgs[2].Name).To(Equal("etcd-backup"))
+	})
+
+	It("should search package names by keyword", func() {
+		l := experimentalaction.NewOperatorListAvailable(&cfg)
+		l.Keyword = "etcd"
+		pkgs, err := l.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(pkgs).To(HaveLen(2))
+		Expect(pkgs[0].Name).To(Equal("etcd"))
+		Expect(pkgs[1].Name).To(Equal("etcd-backup"))
+	})
+
+	It("should fail for a catalog that is not unpacked", func() {
+		l := experimentalaction.NewOperatorListAvailable(&cfg)
+		l.Catalog = "pending"
+		_, err := l.Run(context.TODO())
+		Expect(err).To(MatchError(`catalog "pending" has no content; it may not be unpacked yet`))
+	})
+
+	It("should fail for a missing catalog", func() {
+		l := experimentalaction.NewOperatorListAvailable(&cfg)
+		l.Catalog = "missing"
+		_, err := l.Run(context.TODO())
+		Expect(err).To(MatchError(`catalog "missing" not found`))
+	})
+})
@@ -0,0 +1,348 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	olmv1 "github.com/operator-framework/operator-controller/api/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// operator-controller installs bundles as helm releases named after the
+// cluster extension in its install namespace. Helm adopts existing objects
+// that carry this release metadata instead of refusing to overwrite them.
+const (
+	helmReleaseNameAnnotation      = "meta.helm.sh/release-name"
+	helmReleaseNamespaceAnnotation = "meta.helm.sh/release-namespace"
+	helmManagedByLabel             = "app.kubernetes.io/managed-by"
+	helmManagedByValue             = "Helm"
+)
+
+// OperatorMigrate replaces an operator installed by OLMv0 with a cluster
+// extension pinned to the installed version. The operator's CRDs are handed
+// over to the cluster extension, so they and the operands are never deleted.
+// The subscription and CSV are only removed once the cluster extension is
+// installed.
+type OperatorMigrate struct {
+	config *action.Configuration
+
+	Package string
+
+	// InstallNamespace defaults to the namespace of the subscription.
+	InstallNamespace string
+	ServiceAccount   string
+
+	// DryRun only reports what the migration would do and what prevents it.
+	DryRun bool
+
+	Logf func(string, ...interface{})
+}
+
+func NewOperatorMigrate(cfg *action.Configuration) *OperatorMigrate {
+	return &OperatorMigrate{
+		config: cfg,
+		Logf:   func(string, ...interface{}) {},
+	}
+}
+
+// OperatorMigration is the plan for migrating an operator.
+type OperatorMigration struct {
+	Subscription     *v1alpha1.Subscription
+	CSV              *v1alpha1.ClusterServiceVersion
+	ClusterExtension *olmv1.ClusterExtension
+
+	// CRDs are the names of the CRDs handed over to the cluster extension.
+	CRDs []string
+
+	// Problems prevent the migration.
+	Problems []string
+
+	// Warnings describe how the operator behaves differently after the
+	// migration.
+	Warnings []string
+}
+
+func (m *OperatorMigration) problemf(format string, args ...interface{}) {
+	m.Problems = append(m.Problems, fmt.Sprintf(format, args...))
+}
+
+func (m *OperatorMigration) warnf(format string, args ...interface{}) {
+	m.Warnings = append(m.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Run plans the migration and, unless DryRun is set or the plan has
+// problems, carries it out. The plan is returned in either case.
+func (m *OperatorMigrate) Run(ctx context.Context) (*OperatorMigration, error) {
+	plan, err := m.plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if m.DryRun {
+		return plan, nil
+	}
+	if len(plan.Problems) > 0 {
+		return plan, fmt.Errorf("operator %q cannot be migrated: %s", m.Package, strings.Join(plan.Problems, "; "))
+	}
+
+	for _, name := range plan.CRDs {
+		if err := m.handOverCRD(ctx, name, plan.ClusterExtension); err != nil {
+			return plan, err
+		}
+		m.Logf("customresourcedefinition %q handed over to clusterextension %q", name, plan.ClusterExtension.Name)
+	}
+
+	ext := plan.ClusterExtension.DeepCopy()
+	if err := m.config.Client.Create(ctx, ext); err != nil {
+		return plan, fmt.Errorf("create clusterextension %q: %v", ext.Name, err)
+	}
+	m.Logf("clusterextension %q created", ext.Name)
+	if err := waitForInstalled(ctx, m.config, ext, m.Logf); err != nil {
+		return plan, err
+	}
+	plan.ClusterExtension = ext
+
+	if err := m.config.Client.Delete(ctx, plan.Subscription); err != nil && !apierrors.IsNotFound(err) {
+		return plan, fmt.Errorf("delete subscription %q: %v", plan.Subscription.Name, err)
+	}
+	m.Logf("subscription %q deleted", plan.Subscription.Name)
+	if err := m.config.Client.Delete(ctx, plan.CSV); err != nil && !apierrors.IsNotFound(err) {
+		return plan, fmt.Errorf("delete clusterserviceversion %q: %v", plan.CSV.Name, err)
+	}
+	if err := waitForDeletion(ctx, m.config.Client, plan.Subscription, plan.CSV); err != nil {
+		return plan, err
+	}
+	m.Logf("clusterserviceversion %q deleted", plan.CSV.Name)
+	return plan, nil
+}
+
+func (m *OperatorMigrate) plan(ctx context.Context) (*OperatorMigration, error) {
+	plan := &OperatorMigration{}
+
+	subs := v1alpha1.SubscriptionList{}
+	if err := m.config.Client.List(ctx, &subs, client.InNamespace(m.config.Namespace)); err != nil {
+		return nil, fmt.Errorf("list subscriptions: %v", err)
+	}
+	for i := range subs.Items {
+		if subs.Items[i].Spec.Package == m.Package {
+			plan.Subscription = &subs.Items[i]
+			break
+		}
+	}
+	if plan.Subscription == nil {
+		return nil, fmt.Errorf("subscription for package %q not found in namespace %q", m.Package, m.config.Namespace)
+	}
+	plan.Subscription.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.SubscriptionKind))
+	if plan.Subscription.Status.InstalledCSV == "" {
+		return nil, fmt.Errorf("subscription %q has no installed clusterserviceversion", plan.Subscription.Name)
+	}
+
+	plan.CSV = &v1alpha1.ClusterServiceVersion{}
+	csvKey := types.NamespacedName{Namespace: m.config.Namespace, Name: plan.Subscription.Status.InstalledCSV}
+	if err := m.config.Client.Get(ctx, csvKey, plan.CSV); err != nil {
+		return nil, fmt.Errorf("get clusterserviceversion %q: %v", csvKey.Name, err)
+	}
+	plan.CSV.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.ClusterServiceVersionKind))
+	csv := plan.CSV
+
+	installNamespace := m.InstallNamespace
+	if installNamespace == "" {
+		installNamespace = m.config.Namespace
+	}
+	version := csv.Spec.Version.String()
+	plan.ClusterExtension = &olmv1.ClusterExtension{
+		TypeMeta:   metav1.TypeMeta{APIVersion: olmv1.GroupVersion.String(), Kind: olmv1.ClusterExtensionKind},
+		ObjectMeta: metav1.ObjectMeta{Name: m.Package},
+		Spec: olmv1.ClusterExtensionSpec{
+			PackageName:      m.Package,
+			Version:          version,
+			InstallNamespace: installNamespace,
+			ServiceAccount:   olmv1.ServiceAccountReference{Name: m.ServiceAccount},
+		},
+	}
+
+	if csv.Status.Phase != v1alpha1.CSVPhaseSucceeded {
+		plan.problemf("clusterserviceversion %q is in phase %q; only healthy operators can be migrated", csv.Name, csv.Status.Phase)
+	}
+	m.checkCompatible(plan)
+	if err := m.checkOperatorGroup(ctx, plan); err != nil {
+		return nil, err
+	}
+	if err := m.checkInstallTarget(ctx, plan); err != nil {
+		return nil, err
+	}
+	m.checkAvailable(ctx, plan)
+
+	for _, desc := range csv.Spec.CustomResourceDefinitions.Owned {
+		crd := apiextensionsv1.CustomResourceDefinition{}
+		if err := m.config.Client.Get(ctx, types.NamespacedName{Name: desc.Name}, &crd); err != nil {
+			if apierrors.IsNotFound(err) {
+				plan.warnf("owned customresourcedefinition %q does not exist; the cluster extension will create it", desc.Name)
+				continue
+			}
+			return nil, fmt.Errorf("get customresourcedefinition %q: %v", desc.Name, err)
+		}
+		if !sets.New(plan.CRDs...).Has(desc.Name) {
+			plan.CRDs = append(plan.CRDs, desc.Name)
+		}
+	}
+	return plan, nil
+}
+
+// checkCompatible reports CSV features that OLMv1 does not support.
+func (m *OperatorMigrate) checkCompatible(plan *OperatorMigration) {
+	csv := plan.CSV
+	allNamespaces := false
+	for _, mode := range csv.Spec.InstallModes {
+		if mode.Type == v1alpha1.InstallModeTypeAllNamespaces && mode.Supported {
+			allNamespaces = true
+		}
+	}
+	if !allNamespaces {
+		plan.problemf("clusterserviceversion %q does not support the AllNamespaces install mode", csv.Name)
+	}
+	for _, desc := range csv.Spec.CustomResourceDefinitions.Required {
+		plan.problemf("clusterserviceversion %q depends on API %s provided by another operator", csv.Name, desc.Name)
+	}
+	for _, desc := range csv.Spec.APIServiceDefinitions.Required {
+		plan.problemf("clusterserviceversion %q depends on API %s.%s provided by another operator", csv.Name, desc.Name, desc.Group)
+	}
+	for _, desc := range csv.Spec.APIServiceDefinitions.Owned {
+		plan.problemf("clusterserviceversion %q provides API service %s.%s, which OLMv1 does not support", csv.Name, desc.Name, desc.Group)
+	}
+	for _, wh := range csv.Spec.WebhookDefinitions {
+		plan.problemf("clusterserviceversion %q defines %s webhook %q, which OLMv1 does not support", csv.Name, wh.Type, wh.GenerateName)
+	}
+}
+
+// checkOperatorGroup warns if the operator watches fewer namespaces than it
+// will as a cluster extension.
+func (m *OperatorMigrate) checkOperatorGroup(ctx context.Context, plan *OperatorMigration) error {
+	ogName := plan.CSV.GetAnnotations()[v1.OperatorGroupAnnotationKey]
+	if ogName == "" {
+		return nil
+	}
+	og := v1.OperatorGroup{}
+	if err := m.config.Client.Get(ctx, types.NamespacedName{Namespace: m.config.Namespace, Name: ogName}, &og); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("get operator group %q: %v", ogName, err)
+	}
+	targets := og.Status.Namespaces
+	if len(targets) == 0 {
+		targets = og.Spec.TargetNamespaces
+	}
+	if len(targets) > 0 && !(len(targets) == 1 && targets[0] == corev1.NamespaceAll) {
+		plan.warnf("operator group %q limits the operator to namespaces %q; after migration the operator watches all namespaces", og.Name, strings.Join(targets, ","))
+	}
+	return nil
+}
+
+// checkInstallTarget checks the install namespace, service account and
+// cluster extension name are usable.
+func (m *OperatorMigrate) checkInstallTarget(ctx context.Context, plan *OperatorMigration) error {
+	spec := plan.ClusterExtension.Spec
+
+	existing := olmv1.ClusterExtension{}
+	if err := m.config.Client.Get(ctx, types.NamespacedName{Name: plan.ClusterExtension.Name}, &existing); err == nil {
+		plan.problemf("clusterextension %q already exists", existing.Name)
+	} else if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("get clusterextension %q: %v", plan.ClusterExtension.Name, err)
+	}
+
+	ns := corev1.Namespace{}
+	if err := m.config.Client.Get(ctx, types.NamespacedName{Name: spec.InstallNamespace}, &ns); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("get namespace %q: %v", spec.InstallNamespace, err)
+		}
+		plan.problemf("install namespace %q does not exist", spec.InstallNamespace)
+	}
+
+	if spec.ServiceAccount.Name == "" {
+		plan.problemf("a service account in namespace %q is required to install the cluster extension", spec.InstallNamespace)
+	} else {
+		sa := corev1.ServiceAccount{}
+		if err := m.config.Client.Get(ctx, types.NamespacedName{Namespace: spec.InstallNamespace, Name: spec.ServiceAccount.Name}, &sa); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("get service account %q: %v", spec.ServiceAccount.Name, err)
+			}
+			plan.problemf("service account %q does not exist in namespace %q", spec.ServiceAccount.Name, spec.InstallNamespace)
+		}
+	}
+
+	// The CSV's deployments keep running until the cluster extension is
+	// installed, so the cluster extension cannot create deployments with the
+	// same names in the same namespace.
+	if spec.InstallNamespace == plan.CSV.Namespace {
+		for _, d := range plan.CSV.Spec.InstallStrategy.StrategySpec.DeploymentSpecs {
+			plan.problemf("deployment %q of clusterserviceversion %q would conflict with the cluster extension's; choose a different install namespace", d.Name, plan.CSV.Name)
+		}
+	}
+	return nil
+}
+
+// checkAvailable checks a catalog serves the installed version, so the
+// cluster extension installs the same bundle.
+func (m *OperatorMigrate) checkAvailable(ctx context.Context, plan *OperatorMigration) {
+	l := NewOperatorListAvailable(m.config)
+	l.Package = m.Package
+	l.Logf = m.Logf
+	pkgs, err := l.Run(ctx)
+	if err != nil {
+		plan.problemf("cannot read catalog content: %v", err)
+		return
+	}
+	version := plan.ClusterExtension.Spec.Version
+	for _, p := range pkgs {
+		for _, c := range p.Channels {
+			if sets.New(c.Versions...).Has(version) {
+				if c.Name == plan.Subscription.Spec.Channel {
+					plan.ClusterExtension.Spec.Channel = c.Name
+				}
+				return
+			}
+		}
+	}
+	plan.problemf("no catalog serves version %q of package %q", version, m.Package)
+}
+
+// handOverCRD adds helm release metadata to the CRD, so the cluster
+// extension adopts it rather than failing because it already exists.
+func (m *OperatorMigrate) handOverCRD(ctx context.Context, name string, ext *olmv1.ClusterExtension) error {
+	if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		crd := apiextensionsv1.CustomResourceDefinition{}
+		if err := m.config.Client.Get(ctx, types.NamespacedName{Name: name}, &crd); err != nil {
+			return err
+		}
+		annotations := crd.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[helmReleaseNameAnnotation] = ext.Name
+		annotations[helmReleaseNamespaceAnnotation] = ext.Spec.InstallNamespace
+		crd.SetAnnotations(annotations)
+		labels := crd.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[helmManagedByLabel] = helmManagedByValue
+		crd.SetLabels(labels)
+		return m.config.Client.Update(ctx, &crd)
+	}); err != nil {
+		return fmt.Errorf("hand over customresourcedefinition %q: %v", name, err)
+	}
+	return nil
+}
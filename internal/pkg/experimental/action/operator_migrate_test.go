@@ -0,0 +1,177 @@
+package action_test
+
+import (
+	"context"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	olmv1 "github.com/operator-framework/operator-controller/api/v1alpha1"
+
+	experimentalaction "github.com/operator-framework/kubectl-operator/internal/pkg/experimental/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("OperatorMigrate", func() {
+	var (
+		cfg action.Configuration
+		srv *httptest.Server
+		cat *unstructured.Unstructured
+		csv *v1alpha1.ClusterServiceVersion
+	)
+
+	BeforeEach(func() {
+		srv, cat = newCatalogServer("operatorhubio", operatorhubioFBC)
+
+		csv = &v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "etcdoperator.v0.9.4",
+				Namespace:   "operators",
+				Annotations: map[string]string{v1.OperatorGroupAnnotationKey: "global-operators"},
+			},
+			Spec: v1alpha1.ClusterServiceVersionSpec{
+				InstallModes: []v1alpha1.InstallMode{{Type: v1alpha1.InstallModeTypeAllNamespaces, Supported: true}},
+				CustomResourceDefinitions: v1alpha1.CustomResourceDefinitions{
+					Owned: []v1alpha1.CRDDescription{{Name: "etcdclusters.etcd.database.coreos.com", Version: "v1beta2", Kind: "EtcdCluster"}},
+				},
+				InstallStrategy: v1alpha1.NamedInstallStrategy{
+					StrategySpec: v1alpha1.StrategyDetailsDeployment{
+						DeploymentSpecs: []v1alpha1.StrategyDeploymentSpec{{Name: "etcd-operator"}},
+					},
+				},
+			},
+			Status: v1alpha1.ClusterServiceVersionStatus{Phase: v1alpha1.CSVPhaseSucceeded},
+		}
+		Expect(csv.Spec.Version.UnmarshalJSON([]byte(`"0.9.4"`))).To(Succeed())
+	})
+
+	AfterEach(func() {
+		srv.Close()
+	})
+
+	build := func() {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+		cfg.Scheme = sch
+		cfg.Namespace = "operators"
+		cfg.Client = fake.NewClientBuilder().WithScheme(sch).WithObjects(
+			cat,
+			csv,
+			&v1alpha1.Subscription{
+				ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "operators"},
+				Spec:       &v1alpha1.SubscriptionSpec{Package: "etcd", Channel: "singlenamespace-alpha"},
+				Status:     v1alpha1.SubscriptionStatus{InstalledCSV: "etcdoperator.v0.9.4"},
+			},
+			&v1.OperatorGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "global-operators", Namespace: "operators"},
+				Status:     v1.OperatorGroupStatus{Namespaces: []string{corev1.NamespaceAll}},
+			},
+			&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "etcdclusters.etcd.database.coreos.com"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "etcd-system"}},
+			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "etcd-installer", Namespace: "etcd-system"}},
+		).WithInterceptorFuncs(interceptor.Funcs{
+			// The fake client has no operator-controller; install every
+			// cluster extension as soon as it is created.
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if ext, ok := obj.(*olmv1.ClusterExtension); ok {
+					meta.SetStatusCondition(&ext.Status.Conditions, metav1.Condition{
+						Type:   olmv1.TypeInstalled,
+						Status: metav1.ConditionTrue,
+						Reason: olmv1.ReasonSuccess,
+					})
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).Build()
+	}
+
+	newMigrate := func() *experimentalaction.OperatorMigrate {
+		m := experimentalaction.NewOperatorMigrate(&cfg)
+		m.Package = "etcd"
+		m.InstallNamespace = "etcd-system"
+		m.ServiceAccount = "etcd-installer"
+		return m
+	}
+
+	exists := func(key types.NamespacedName, obj client.Object) bool {
+		err := cfg.Client.Get(context.TODO(), key, obj)
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		Expect(err).To(BeNil())
+		return true
+	}
+
+	It("should report the plan without changing anything", func() {
+		build()
+		m := newMigrate()
+		m.DryRun = true
+		plan, err := m.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(plan.Problems).To(BeEmpty())
+		Expect(plan.CRDs).To(Equal([]string{"etcdclusters.etcd.database.coreos.com"}))
+		Expect(plan.ClusterExtension.Spec).To(Equal(olmv1.ClusterExtensionSpec{
+			PackageName:      "etcd",
+			Version:          "0.9.4",
+			Channel:          "singlenamespace-alpha",
+			InstallNamespace: "etcd-system",
+			ServiceAccount:   olmv1.ServiceAccountReference{Name: "etcd-installer"},
+		}))
+
+		Expect(exists(types.NamespacedName{Name: "etcd"}, &olmv1.ClusterExtension{})).To(BeFalse())
+		Expect(exists(types.NamespacedName{Namespace: "operators", Name: "etcd"}, &v1alpha1.Subscription{})).To(BeTrue())
+	})
+
+	It("should report operators that OLMv1 cannot install", func() {
+		csv.Spec.InstallModes = []v1alpha1.InstallMode{{Type: v1alpha1.InstallModeTypeOwnNamespace, Supported: true}}
+		csv.Spec.WebhookDefinitions = []v1alpha1.WebhookDescription{{Type: v1alpha1.ValidatingAdmissionWebhook, GenerateName: "vetcdcluster.kb.io"}}
+		csv.Spec.CustomResourceDefinitions.Required = []v1alpha1.CRDDescription{{Name: "backups.example.com"}}
+		build()
+
+		m := newMigrate()
+		m.InstallNamespace = ""
+		m.ServiceAccount = ""
+		plan, err := m.Run(context.TODO())
+		Expect(err).To(MatchError(ContainSubstring(`operator "etcd" cannot be migrated`)))
+		Expect(plan.Problems).To(ConsistOf(
+			`clusterserviceversion "etcdoperator.v0.9.4" does not support the AllNamespaces install mode`,
+			`clusterserviceversion "etcdoperator.v0.9.4" depends on API backups.example.com provided by another operator`,
+			`clusterserviceversion "etcdoperator.v0.9.4" defines ValidatingAdmissionWebhook webhook "vetcdcluster.kb.io", which OLMv1 does not support`,
+			`install namespace "operators" does not exist`,
+			`a service account in namespace "operators" is required to install the cluster extension`,
+			`deployment "etcd-operator" of clusterserviceversion "etcdoperator.v0.9.4" would conflict with the cluster extension's; choose a different install namespace`,
+		))
+		Expect(exists(types.NamespacedName{Name: "etcd"}, &olmv1.ClusterExtension{})).To(BeFalse())
+	})
+
+	It("should hand over the CRDs and replace the subscription and CSV", func() {
+		build()
+		plan, err := newMigrate().Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(plan.ClusterExtension.Spec.Version).To(Equal("0.9.4"))
+
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		Expect(exists(types.NamespacedName{Name: "etcdclusters.etcd.database.coreos.com"}, crd)).To(BeTrue())
+		Expect(crd.Annotations).To(HaveKeyWithValue("meta.helm.sh/release-name", "etcd"))
+		Expect(crd.Annotations).To(HaveKeyWithValue("meta.helm.sh/release-namespace", "etcd-system"))
+		Expect(crd.Labels).To(HaveKeyWithValue("app.kubernetes.io/managed-by", "Helm"))
+
+		Expect(exists(types.NamespacedName{Name: "etcd"}, &olmv1.ClusterExtension{})).To(BeTrue())
+		Expect(exists(types.NamespacedName{Namespace: "operators", Name: "etcd"}, &v1alpha1.Subscription{})).To(BeFalse())
+		Expect(exists(types.NamespacedName{Namespace: "operators", Name: "etcdoperator.v0.9.4"}, &v1alpha1.ClusterServiceVersion{})).To(BeFalse())
+	})
+})
@@ -5,42 +5,283 @@ import (
 	"fmt"
 	"strings"
 
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	olmv1 "github.com/operator-framework/operator-controller/api/v1alpha1"
 
+	"github.com/operator-framework/kubectl-operator/internal/pkg/operand"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
+const (
+	// operator-controller labels the objects of a cluster extension with
+	// their owner.
+	ownerKindLabel = "olm.operatorframework.io/owner-kind"
+	ownerNameLabel = "olm.operatorframework.io/owner-name"
+)
+
 type OperatorUninstall struct {
 	config *action.Configuration
 
-	Package string
+	Package         string
+	OperandStrategy operand.DeletionStrategy
+
+	// Discovery is used to find the objects of the cluster extension's
+	// bundle with the "ignore" strategy. If nil, it is built from the
+	// configuration's REST config.
+	Discovery discovery.DiscoveryInterface
 
 	Logf func(string, ...interface{})
 }
 
 func NewOperatorUninstall(cfg *action.Configuration) *OperatorUninstall {
 	return &OperatorUninstall{
-		config: cfg,
-		Logf:   func(string, ...interface{}) {},
+		config:          cfg,
+		OperandStrategy: operand.Abort,
+		Logf:            func(string, ...interface{}) {},
 	}
 }
 
 func (u *OperatorUninstall) Run(ctx context.Context) error {
-	opKey := types.NamespacedName{Name: u.Package}
-	op := &olmv1.ClusterExtension{}
-	op.SetName(opKey.Name)
-	op.SetGroupVersionKind(olmv1.GroupVersion.WithKind("Operator"))
+	if err := u.OperandStrategy.Valid(); err != nil {
+		return err
+	}
+
+	ext, err := getClusterExtension(ctx, u.config, u.Package)
+	if err != nil {
+		return err
+	}
+	ext.SetGroupVersionKind(olmv1.GroupVersion.WithKind(olmv1.ClusterExtensionKind))
+
+	crds, err := installedCRDs(ctx, u.config, ext)
+	if err != nil {
+		return err
+	}
+	var operands []unstructured.Unstructured
+	for _, crd := range crds {
+		crs, err := listCustomResources(ctx, u.config, crd)
+		if err != nil {
+			return err
+		}
+		if len(crs) > 0 {
+			u.Logf("customresourcedefinition %q has %d operand(s)", crd.Name, len(crs))
+		}
+		operands = append(operands, crs...)
+	}
+	if len(operands) > 0 && u.OperandStrategy == operand.Abort {
+		return fmt.Errorf("could not proceed with deletion of %q: %w", u.Package, operand.ErrAbortStrategy)
+	}
+
+	/*
+		The objects of the cluster extension's bundle, including the CRDs, are
+		owned by it, so deleting it garbage collects the CRDs, which cascades
+		to the deletion of every operand. So:
+			- with "ignore", the CRDs are released from the cluster extension
+			  and it is deleted without garbage collecting its objects, since
+			  operator-controller may own the CRDs again until it is gone. The
+			  other objects of the bundle are then deleted here, leaving the
+			  CRDs and operands on the cluster;
+			- with "delete", the operands are deleted first, while the operator
+			  is still running to handle their finalizers.
+	*/
+	switch u.OperandStrategy {
+	case operand.Ignore:
+		return u.uninstallKeepingCRDs(ctx, ext, crds, operands)
+	case operand.Delete:
+		objs := make([]client.Object, 0, len(operands))
+		for i := range operands {
+			objs = append(objs, &operands[i])
+		}
+		if err := u.deleteObjects(ctx, objs); err != nil {
+			return fmt.Errorf("delete operands: %v", err)
+		}
+	}
+
+	return u.deleteObjects(ctx, []client.Object{ext})
+}
+
+// uninstallKeepingCRDs deletes the cluster extension and the objects of its
+// bundle other than the CRDs, leaving the CRDs and their operands behind.
+func (u *OperatorUninstall) uninstallKeepingCRDs(ctx context.Context, ext *olmv1.ClusterExtension, crds []apiextensionsv1.CustomResourceDefinition, operands []unstructured.Unstructured) error {
+	if u.Discovery == nil {
+		if u.config.RESTConfig == nil {
+			return fmt.Errorf("a discovery client is required to find the objects of clusterextension %q", ext.Name)
+		}
+		dc, err := discovery.NewDiscoveryClientForConfig(u.config.RESTConfig)
+		if err != nil {
+			return fmt.Errorf("create discovery client: %v", err)
+		}
+		u.Discovery = dc
+	}
+
+	for i := range crds {
+		if err := orphanCRD(ctx, u.config, ext, crds[i].Name); err != nil {
+			return err
+		}
+	}
+	if err := u.deleteObjects(ctx, []client.Object{ext}, client.PropagationPolicy(metav1.DeletePropagationOrphan)); err != nil {
+		return err
+	}
+	// operator-controller may have applied the bundle again between the
+	// CRDs being released and the cluster extension being deleted.
+	for i := range crds {
+		if err := orphanCRD(ctx, u.config, ext, crds[i].Name); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		u.Logf("customresourcedefinition %q orphaned", crds[i].Name)
+	}
+	for _, op := range operands {
+		u.Logf("%s %q orphaned", strings.ToLower(op.GetKind()), objectName(&op))
+	}
+
+	objs, err := u.releaseObjects(ctx, ext)
+	if err != nil {
+		return err
+	}
+	return u.deleteObjects(ctx, objs)
+}
+
+// releaseObjects returns the objects other than CRDs that carry the owner
+// labels of the cluster extension.
+func (u *OperatorUninstall) releaseObjects(ctx context.Context, ext *olmv1.ClusterExtension) ([]client.Object, error) {
+	_, resourceLists, err := u.Discovery.ServerGroupsAndResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, fmt.Errorf("discover resources: %v", err)
+	}
+	crdGroupKind := apiextensionsv1.Kind("CustomResourceDefinition")
+	seen := map[schema.GroupResource]bool{}
+	var objs []client.Object
+	for _, resourceList := range resourceLists {
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, r := range resourceList.APIResources {
+			gr := gv.WithResource(r.Name).GroupResource()
+			if strings.Contains(r.Name, "/") || seen[gr] || gv.WithKind(r.Kind).GroupKind() == crdGroupKind ||
+				!sets.New[string](r.Verbs...).HasAll("list", "delete") {
+				continue
+			}
+			seen[gr] = true
+
+			list := unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(gv.WithKind(r.Kind + "List"))
+			if err := u.config.Client.List(ctx, &list, client.MatchingLabels{
+				ownerKindLabel: olmv1.ClusterExtensionKind,
+				ownerNameLabel: ext.Name,
+			}); err != nil {
+				return nil, fmt.Errorf("list %s: %v", gr, err)
+			}
+			for i := range list.Items {
+				list.Items[i].SetGroupVersionKind(gv.WithKind(r.Kind))
+				objs = append(objs, &list.Items[i])
+			}
+		}
+	}
+	return objs, nil
+}
+
+func (u *OperatorUninstall) deleteObjects(ctx context.Context, objs []client.Object, opts ...client.DeleteOption) error {
+	for _, obj := range objs {
+		lowerKind := strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind)
+		if err := u.config.Client.Delete(ctx, obj, opts...); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("delete %s %q: %v", lowerKind, objectName(obj), err)
+		} else if err == nil {
+			u.Logf("%s %q deleted", lowerKind, objectName(obj))
+		}
+	}
+	return waitForDeletion(ctx, u.config.Client, objs...)
+}
+
+// installedCRDs returns the CRDs that the cluster extension installed or
+// adopted, found by operator-controller's owner labels or by the metadata
+// of the helm release it installed the bundle as.
+func installedCRDs(ctx context.Context, cfg *action.Configuration, ext *olmv1.ClusterExtension) ([]apiextensionsv1.CustomResourceDefinition, error) {
+	crds := apiextensionsv1.CustomResourceDefinitionList{}
+	if err := cfg.Client.List(ctx, &crds); err != nil {
+		return nil, fmt.Errorf("list customresourcedefinitions: %v", err)
+	}
+	var installed []apiextensionsv1.CustomResourceDefinition
+	for _, crd := range crds.Items {
+		labels, annotations := crd.GetLabels(), crd.GetAnnotations()
+		ownedByLabel := labels[ownerKindLabel] == olmv1.ClusterExtensionKind && labels[ownerNameLabel] == ext.Name
+		ownedByRelease := annotations[helmReleaseNameAnnotation] == ext.Name && annotations[helmReleaseNamespaceAnnotation] == ext.Spec.InstallNamespace
+		if ownedByLabel || ownedByRelease {
+			installed = append(installed, crd)
+		}
+	}
+	return installed, nil
+}
+
+// listCustomResources lists the custom resources of the CRD in all
+// namespaces.
+func listCustomResources(ctx context.Context, cfg *action.Configuration, crd apiextensionsv1.CustomResourceDefinition) ([]unstructured.Unstructured, error) {
+	version := ""
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			version = v.Name
+		}
+	}
+	if version == "" {
+		return nil, nil
+	}
+	listKind := crd.Spec.Names.ListKind
+	if listKind == "" {
+		listKind = crd.Spec.Names.Kind + "List"
+	}
+	list := unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{Group: crd.Spec.Group, Version: version, Kind: listKind})
+	if err := cfg.Client.List(ctx, &list); err != nil {
+		return nil, fmt.Errorf("list %s: %v", crd.Spec.Names.Plural, err)
+	}
+	return list.Items, nil
+}
+
+// orphanCRD removes the cluster extension's owner reference and owner labels
+// from the CRD, so that it is not garbage collected with the cluster
+// extension.
+func orphanCRD(ctx context.Context, cfg *action.Configuration, ext *olmv1.ClusterExtension, name string) error {
+	if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		crd := apiextensionsv1.CustomResourceDefinition{}
+		if err := cfg.Client.Get(ctx, types.NamespacedName{Name: name}, &crd); err != nil {
+			return err
+		}
+		var refs []metav1.OwnerReference
+		for _, ref := range crd.GetOwnerReferences() {
+			ownedByExt := ref.UID == ext.UID || (ref.Kind == olmv1.ClusterExtensionKind && ref.Name == ext.Name)
+			if !ownedByExt {
+				refs = append(refs, ref)
+			}
+		}
+		crd.SetOwnerReferences(refs)
+		labels := crd.GetLabels()
+		if labels[ownerKindLabel] == olmv1.ClusterExtensionKind && labels[ownerNameLabel] == ext.Name {
+			delete(labels, ownerKindLabel)
+			delete(labels, ownerNameLabel)
+			crd.SetLabels(labels)
+		}
+		return cfg.Client.Update(ctx, &crd)
+	}); err != nil {
+		return fmt.Errorf("orphan customresourcedefinition %q: %w", name, err)
+	}
+	return nil
+}
 
-	lowerKind := strings.ToLower(op.GetObjectKind().GroupVersionKind().Kind)
-	if err := u.config.Client.Delete(ctx, op); err != nil && !apierrors.IsNotFound(err) {
-		return fmt.Errorf("delete %s %q: %v", lowerKind, op.GetName(), err)
+func objectName(obj client.Object) string {
+	if obj.GetNamespace() != "" {
+		return obj.GetNamespace() + "/" + obj.GetName()
 	}
-	return waitForDeletion(ctx, u.config.Client, op)
+	return obj.GetName()
 }
 
 func objectKeyForObject(obj client.Object) types.NamespacedName {
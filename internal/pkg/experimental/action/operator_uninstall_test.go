@@ -0,0 +1,210 @@
+package action_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	olmv1 "github.com/operator-framework/operator-controller/api/v1alpha1"
+
+	experimentalaction "github.com/operator-framework/kubectl-operator/internal/pkg/experimental/action"
+	"github.com/operator-framework/kubectl-operator/internal/pkg/operand"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("OperatorUninstall", func() {
+	var (
+		cfg   action.Configuration
+		objs  []client.Object
+		logs  []string
+		funcs interceptor.Funcs
+	)
+
+	ownerLabels := map[string]string{
+		"olm.operatorframework.io/owner-kind": "ClusterExtension",
+		"olm.operatorframework.io/owner-name": "etcd",
+	}
+	extOwnerRef := metav1.OwnerReference{APIVersion: olmv1.GroupVersion.String(), Kind: "ClusterExtension", Name: "etcd", UID: "etcd-uid"}
+
+	newCRD := func(name, group, kind string, labels map[string]string) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Group:    group,
+				Names:    apiextensionsv1.CustomResourceDefinitionNames{Kind: kind, ListKind: kind + "List", Plural: name[:len(name)-len(group)-1]},
+				Scope:    apiextensionsv1.NamespaceScoped,
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1beta2", Served: true, Storage: true}},
+			},
+		}
+	}
+
+	newCR := func(group, kind, namespace, name string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion(group + "/v1beta2")
+		u.SetKind(kind)
+		u.SetNamespace(namespace)
+		u.SetName(name)
+		return u
+	}
+
+	BeforeEach(func() {
+		logs = nil
+		funcs = interceptor.Funcs{}
+		objs = []client.Object{
+			&olmv1.ClusterExtension{
+				ObjectMeta: metav1.ObjectMeta{Name: "etcd", UID: "etcd-uid"},
+				Spec:       olmv1.ClusterExtensionSpec{PackageName: "etcd", InstallNamespace: "etcd-system"},
+			},
+			newCRD("etcdclusters.etcd.database.coreos.com", "etcd.database.coreos.com", "EtcdCluster", ownerLabels),
+			// CRDs of other operators must not be considered
+			newCRD("argocds.argoproj.io", "argoproj.io", "ArgoCD", nil),
+			newCR("argoproj.io", "ArgoCD", "argocd", "example"),
+		}
+	})
+
+	build := func() {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+		cfg.Scheme = sch
+		cfg.Client = fake.NewClientBuilder().WithScheme(sch).WithObjects(objs...).WithInterceptorFuncs(funcs).Build()
+	}
+
+	newUninstall := func(strategy operand.DeletionStrategy) *experimentalaction.OperatorUninstall {
+		u := experimentalaction.NewOperatorUninstall(&cfg)
+		u.Package = "etcd"
+		u.OperandStrategy = strategy
+		u.Discovery = &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: []*metav1.APIResourceList{
+			{GroupVersion: "v1", APIResources: []metav1.APIResource{
+				{Name: "serviceaccounts", Kind: "ServiceAccount", Namespaced: true, Verbs: []string{"list", "delete"}},
+			}},
+			{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+				{Name: "deployments", Kind: "Deployment", Namespaced: true, Verbs: []string{"list", "delete"}},
+				{Name: "deployments/status", Kind: "Deployment", Namespaced: true, Verbs: []string{"get"}},
+			}},
+			{GroupVersion: "apiextensions.k8s.io/v1", APIResources: []metav1.APIResource{
+				{Name: "customresourcedefinitions", Kind: "CustomResourceDefinition", Verbs: []string{"list", "delete"}},
+			}},
+		}}}
+		u.Logf = func(format string, args ...interface{}) {
+			logs = append(logs, fmt.Sprintf(format, args...))
+		}
+		return u
+	}
+
+	exists := func(key types.NamespacedName, obj client.Object) bool {
+		err := cfg.Client.Get(context.TODO(), key, obj)
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		Expect(err).To(BeNil())
+		return true
+	}
+
+	Context("without operands", func() {
+		It("should delete the cluster extension", func() {
+			build()
+			Expect(newUninstall(operand.Abort).Run(context.TODO())).To(Succeed())
+			Expect(exists(types.NamespacedName{Name: "etcd"}, &olmv1.ClusterExtension{})).To(BeFalse())
+			Expect(logs).To(Equal([]string{`clusterextension "etcd" deleted`}))
+		})
+	})
+
+	It("should fail for a missing cluster extension", func() {
+		build()
+		u := newUninstall(operand.Abort)
+		u.Package = "missing"
+		Expect(u.Run(context.TODO())).To(MatchError(ContainSubstring(`get clusterextension "missing"`)))
+	})
+
+	Context("with operands", func() {
+		BeforeEach(func() {
+			objs = append(objs,
+				newCR("etcd.database.coreos.com", "EtcdCluster", "team-a", "example"),
+				newCR("etcd.database.coreos.com", "EtcdCluster", "team-b", "example"),
+			)
+		})
+
+		It("should abort by default", func() {
+			build()
+			err := newUninstall(operand.Abort).Run(context.TODO())
+			Expect(errors.Is(err, operand.ErrAbortStrategy)).To(BeTrue())
+			Expect(logs).To(Equal([]string{`customresourcedefinition "etcdclusters.etcd.database.coreos.com" has 2 operand(s)`}))
+			Expect(exists(types.NamespacedName{Name: "etcd"}, &olmv1.ClusterExtension{})).To(BeTrue())
+		})
+
+		It("should keep the CRDs and operands with the ignore strategy", func() {
+			crd := objs[1].(*apiextensionsv1.CustomResourceDefinition)
+			crd.OwnerReferences = []metav1.OwnerReference{
+				extOwnerRef,
+				{APIVersion: "v1", Kind: "ConfigMap", Name: "other", UID: "other-uid"},
+			}
+			objs = append(objs,
+				&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "etcd-operator", Namespace: "etcd-system", Labels: ownerLabels}},
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "etcd-operator", Namespace: "etcd-system", Labels: ownerLabels}},
+				&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "argocd-operator", Namespace: "argocd"}},
+			)
+
+			// The cluster extension is deleted without garbage collecting its
+			// objects, and operator-controller owns the CRD again just before.
+			var deleteOpts client.DeleteOptions
+			funcs.Delete = func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				if _, ok := obj.(*olmv1.ClusterExtension); ok {
+					deleteOpts.ApplyOptions(opts)
+					reowned := &apiextensionsv1.CustomResourceDefinition{}
+					Expect(cl.Get(ctx, types.NamespacedName{Name: "etcdclusters.etcd.database.coreos.com"}, reowned)).To(Succeed())
+					reowned.Labels = ownerLabels
+					reowned.OwnerReferences = append(reowned.OwnerReferences, extOwnerRef)
+					Expect(cl.Update(ctx, reowned)).To(Succeed())
+				}
+				return cl.Delete(ctx, obj, opts...)
+			}
+			build()
+			Expect(newUninstall(operand.Ignore).Run(context.TODO())).To(Succeed())
+			Expect(exists(types.NamespacedName{Name: "etcd"}, &olmv1.ClusterExtension{})).To(BeFalse())
+			Expect(deleteOpts.PropagationPolicy).NotTo(BeNil())
+			Expect(*deleteOpts.PropagationPolicy).To(Equal(metav1.DeletePropagationOrphan))
+
+			Expect(exists(types.NamespacedName{Namespace: "etcd-system", Name: "etcd-operator"}, &appsv1.Deployment{})).To(BeFalse())
+			Expect(exists(types.NamespacedName{Namespace: "etcd-system", Name: "etcd-operator"}, &corev1.ServiceAccount{})).To(BeFalse())
+			Expect(exists(types.NamespacedName{Namespace: "argocd", Name: "argocd-operator"}, &appsv1.Deployment{})).To(BeTrue())
+
+			crd = &apiextensionsv1.CustomResourceDefinition{}
+			Expect(exists(types.NamespacedName{Name: "etcdclusters.etcd.database.coreos.com"}, crd)).To(BeTrue())
+			Expect(crd.OwnerReferences).To(ConsistOf(HaveField("UID", types.UID("other-uid"))))
+			Expect(crd.Labels).NotTo(HaveKey("olm.operatorframework.io/owner-kind"))
+			Expect(crd.Labels).NotTo(HaveKey("olm.operatorframework.io/owner-name"))
+			Expect(exists(types.NamespacedName{Namespace: "team-a", Name: "example"}, newCR("etcd.database.coreos.com", "EtcdCluster", "", ""))).To(BeTrue())
+			Expect(logs).To(ContainElement(`customresourcedefinition "etcdclusters.etcd.database.coreos.com" orphaned`))
+			Expect(logs).To(ContainElement(`etcdcluster "team-a/example" orphaned`))
+		})
+
+		It("should delete the operands before the cluster extension with the delete strategy", func() {
+			build()
+			Expect(newUninstall(operand.Delete).Run(context.TODO())).To(Succeed())
+			Expect(exists(types.NamespacedName{Namespace: "team-a", Name: "example"}, newCR("etcd.database.coreos.com", "EtcdCluster", "", ""))).To(BeFalse())
+			Expect(exists(types.NamespacedName{Namespace: "argocd", Name: "example"}, newCR("argoproj.io", "ArgoCD", "", ""))).To(BeTrue())
+			Expect(logs).To(Equal([]string{
+				`customresourcedefinition "etcdclusters.etcd.database.coreos.com" has 2 operand(s)`,
+				`etcdcluster "team-a/example" deleted`,
+				`etcdcluster "team-b/example" deleted`,
+				`clusterextension "etcd" deleted`,
+			}))
+		})
+	})
+})
@@ -0,0 +1,90 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"k8s.io/client-go/util/retry"
+
+	olmv1 "github.com/operator-framework/operator-controller/api/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// OperatorUpgrade changes the version range or channel of an installed
+// cluster extension and waits for operator-controller to install a bundle
+// that matches them.
+type OperatorUpgrade struct {
+	config *action.Configuration
+
+	Name string
+
+	Version                 string
+	Channel                 string
+	UpgradeConstraintPolicy string
+
+	Logf func(string, ...interface{})
+}
+
+func NewOperatorUpgrade(cfg *action.Configuration) *OperatorUpgrade {
+	return &OperatorUpgrade{
+		config: cfg,
+		Logf:   func(string, ...interface{}) {},
+	}
+}
+
+func (u *OperatorUpgrade) Run(ctx context.Context) (*olmv1.ClusterExtension, error) {
+	if u.Version == "" && u.Channel == "" {
+		return nil, fmt.Errorf("a version or channel to upgrade to is required")
+	}
+	var constraint *semver.Constraints
+	if u.Version != "" {
+		var err error
+		if constraint, err = semver.NewConstraint(u.Version); err != nil {
+			return nil, fmt.Errorf("parse version range %q: %v", u.Version, err)
+		}
+	}
+	policy, err := parseUpgradeConstraintPolicy(u.UpgradeConstraintPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	var ext *olmv1.ClusterExtension
+	if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		var err error
+		if ext, err = getClusterExtension(ctx, u.config, u.Name); err != nil {
+			return err
+		}
+		if u.Version != "" {
+			ext.Spec.Version = u.Version
+		}
+		if u.Channel != "" {
+			ext.Spec.Channel = u.Channel
+		}
+		if policy != "" {
+			ext.Spec.UpgradeConstraintPolicy = policy
+		}
+		return u.config.Client.Update(ctx, ext)
+	}); err != nil {
+		return nil, fmt.Errorf("update clusterextension %q: %v", u.Name, err)
+	}
+
+	if err := waitForInstalled(ctx, u.config, ext, u.Logf); err != nil {
+		return nil, err
+	}
+	if constraint != nil {
+		installed := ext.Status.InstalledBundle
+		if installed == nil {
+			return nil, fmt.Errorf("clusterextension %q is installed but reports no installed bundle", u.Name)
+		}
+		v, err := semver.NewVersion(installed.Version)
+		if err != nil {
+			return nil, fmt.Errorf("parse version %q of installed bundle %q: %v", installed.Version, installed.Name, err)
+		}
+		if !constraint.Check(v) {
+			return nil, fmt.Errorf("installed bundle %q version %q does not satisfy version range %q", installed.Name, installed.Version, u.Version)
+		}
+	}
+	return ext, nil
+}
@@ -0,0 +1,117 @@
+package action_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	olmv1 "github.com/operator-framework/operator-controller/api/v1alpha1"
+
+	experimentalaction "github.com/operator-framework/kubectl-operator/internal/pkg/experimental/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("OperatorList, OperatorDescribe and OperatorUpgrade", func() {
+	var (
+		cfg action.Configuration
+		// installed is the bundle the fake operator-controller installs
+		// when the cluster extension is updated.
+		installed olmv1.BundleMetadata
+	)
+
+	BeforeEach(func() {
+		installed = olmv1.BundleMetadata{Name: "etcdoperator.v0.9.4", Version: "0.9.4"}
+
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+		cfg.Scheme = sch
+		cfg.Client = fake.NewClientBuilder().WithScheme(sch).WithObjects(
+			&olmv1.ClusterExtension{
+				ObjectMeta: metav1.ObjectMeta{Name: "etcd"},
+				Spec: olmv1.ClusterExtensionSpec{
+					PackageName:      "etcd",
+					Version:          "0.9.2",
+					InstallNamespace: "etcd-namespace",
+					ServiceAccount:   olmv1.ServiceAccountReference{Name: "etcd-installer"},
+				},
+				Status: olmv1.ClusterExtensionStatus{
+					InstalledBundle: &olmv1.BundleMetadata{Name: "etcdoperator.v0.9.2", Version: "0.9.2"},
+					ResolvedBundle:  &olmv1.BundleMetadata{Name: "etcdoperator.v0.9.2", Version: "0.9.2"},
+				},
+			},
+			&olmv1.ClusterExtension{ObjectMeta: metav1.ObjectMeta{Name: "argocd"}},
+		).WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if ext, ok := obj.(*olmv1.ClusterExtension); ok {
+					bundle := installed
+					ext.Status.InstalledBundle = &bundle
+					ext.Status.ResolvedBundle = &bundle
+					meta.SetStatusCondition(&ext.Status.Conditions, metav1.Condition{
+						Type:               olmv1.TypeInstalled,
+						Status:             metav1.ConditionTrue,
+						Reason:             olmv1.ReasonSuccess,
+						Message:            "installed " + bundle.Name,
+						ObservedGeneration: ext.Generation,
+					})
+				}
+				return c.Update(ctx, obj, opts...)
+			},
+		}).Build()
+	})
+
+	It("should list cluster extensions by name", func() {
+		exts, err := experimentalaction.NewOperatorList(&cfg).Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(exts).To(HaveLen(2))
+		Expect(exts[0].Name).To(Equal("argocd"))
+		Expect(exts[1].Name).To(Equal("etcd"))
+	})
+
+	It("should describe a cluster extension", func() {
+		d := experimentalaction.NewOperatorDescribe(&cfg)
+		d.Name = "etcd"
+		ext, err := d.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(ext.Status.InstalledBundle.Name).To(Equal("etcdoperator.v0.9.2"))
+
+		d.Name = "missing"
+		_, err = d.Run(context.TODO())
+		Expect(err).To(MatchError(ContainSubstring(`get clusterextension "missing"`)))
+	})
+
+	It("should require a version or channel", func() {
+		u := experimentalaction.NewOperatorUpgrade(&cfg)
+		u.Name = "etcd"
+		_, err := u.Run(context.TODO())
+		Expect(err).To(MatchError("a version or channel to upgrade to is required"))
+	})
+
+	It("should update the version range and wait for the new bundle", func() {
+		u := experimentalaction.NewOperatorUpgrade(&cfg)
+		u.Name = "etcd"
+		u.Version = ">=0.9.4"
+		ext, err := u.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(ext.Spec.Version).To(Equal(">=0.9.4"))
+		Expect(ext.Status.InstalledBundle.Name).To(Equal("etcdoperator.v0.9.4"))
+	})
+
+	It("should fail if the installed bundle is outside the version range", func() {
+		installed = olmv1.BundleMetadata{Name: "etcdoperator.v0.9.2", Version: "0.9.2"}
+		u := experimentalaction.NewOperatorUpgrade(&cfg)
+		u.Name = "etcd"
+		u.Version = ">=0.9.4"
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+		_, err := u.Run(ctx)
+		Expect(err).To(MatchError(`installed bundle "etcdoperator.v0.9.2" version "0.9.2" does not satisfy version range ">=0.9.4"`))
+	})
+})
@@ -0,0 +1,148 @@
+package operator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+)
+
+// ChannelGraph is the upgrade graph of a single package channel.
+type ChannelGraph struct {
+	Channel string
+	Default bool
+	Head    string
+	Entries []ChannelGraphEntry
+
+	// HasEdges is false when the graph was built from packageserver data,
+	// which lists the channel entries but not the upgrade edges between them.
+	HasEdges bool
+}
+
+// ChannelGraphEntry is a bundle in a channel along with its upgrade edges.
+type ChannelGraphEntry struct {
+	Name      string
+	Version   string
+	Replaces  string
+	Skips     []string
+	SkipRange string
+}
+
+// Entry returns the named entry, or false if it is not in the channel.
+func (g ChannelGraph) Entry(name string) (ChannelGraphEntry, bool) {
+	for _, e := range g.Entries {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return ChannelGraphEntry{}, false
+}
+
+// GraphsFromPackageManifest builds a graph for every channel of the package
+// from the entries served by packageserver. These graphs have no edges.
+func GraphsFromPackageManifest(pm PackageManifest) []ChannelGraph {
+	graphs := make([]ChannelGraph, 0, len(pm.Status.Channels))
+	for _, ch := range pm.Status.Channels {
+		g := ChannelGraph{
+			Channel: ch.Name,
+			Default: ch.IsDefaultChannel(pm.PackageManifest),
+			Head:    ch.CurrentCSV,
+		}
+		for _, e := range ch.Entries {
+			g.Entries = append(g.Entries, ChannelGraphEntry{Name: e.Name, Version: e.Version})
+		}
+		if len(g.Entries) == 0 {
+			g.Entries = append(g.Entries, ChannelGraphEntry{
+				Name:    ch.CurrentCSV,
+				Version: ch.CurrentCSVDesc.Version.String(),
+			})
+		}
+		graphs = append(graphs, g)
+	}
+	return graphs
+}
+
+// GraphsFromDeclarativeConfig builds a graph for every channel of the named
+// package in the file-based catalog, including replaces and skips edges.
+func GraphsFromDeclarativeConfig(fbc *declcfg.DeclarativeConfig, packageName string) ([]ChannelGraph, error) {
+	defaultChannel := ""
+	for _, p := range fbc.Packages {
+		if p.Name == packageName {
+			defaultChannel = p.DefaultChannel
+		}
+	}
+
+	versions := map[string]string{}
+	for _, b := range fbc.Bundles {
+		if b.Package != packageName {
+			continue
+		}
+		props, err := property.Parse(b.Properties)
+		if err != nil {
+			return nil, fmt.Errorf("parse properties of bundle %q: %v", b.Name, err)
+		}
+		if len(props.Packages) > 0 {
+			versions[b.Name] = props.Packages[0].Version
+		}
+	}
+
+	graphs := []ChannelGraph{}
+	for _, ch := range fbc.Channels {
+		if ch.Package != packageName {
+			continue
+		}
+		g := ChannelGraph{
+			Channel:  ch.Name,
+			Default:  ch.Name == defaultChannel,
+			HasEdges: true,
+		}
+		for _, e := range ch.Entries {
+			g.Entries = append(g.Entries, ChannelGraphEntry{
+				Name:      e.Name,
+				Version:   versions[e.Name],
+				Replaces:  e.Replaces,
+				Skips:     e.Skips,
+				SkipRange: e.SkipRange,
+			})
+		}
+		head, err := channelHead(g.Entries)
+		if err != nil {
+			return nil, fmt.Errorf("channel %q: %v", ch.Name, err)
+		}
+		g.Head = head
+		graphs = append(graphs, g)
+	}
+	sort.Slice(graphs, func(i, j int) bool {
+		return graphs[i].Channel < graphs[j].Channel
+	})
+	return graphs, nil
+}
+
+// channelHead returns the only entry that no other entry replaces or skips.
+func channelHead(entries []ChannelGraphEntry) (string, error) {
+	incoming := map[string]bool{}
+	for _, e := range entries {
+		if e.Replaces != "" {
+			incoming[e.Replaces] = true
+		}
+		for _, s := range e.Skips {
+			incoming[s] = true
+		}
+	}
+	heads := []string{}
+	for _, e := range entries {
+		if !incoming[e.Name] {
+			heads = append(heads, e.Name)
+		}
+	}
+	switch len(heads) {
+	case 0:
+		return "", fmt.Errorf("no channel head found")
+	case 1:
+		return heads[0], nil
+	default:
+		sort.Strings(heads)
+		return "", fmt.Errorf("multiple channel heads found: %v", heads)
+	}
+}
@@ -0,0 +1,63 @@
+package operator
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+// PossibleInstallModes returns the install modes an operator group in
+// namespace that watches watchNamespaces can serve.
+func PossibleInstallModes(namespace string, watchNamespaces []string) sets.Set[string] {
+	switch len(watchNamespaces) {
+	case 0:
+		return sets.New[string](
+			string(v1alpha1.InstallModeTypeAllNamespaces),
+			string(v1alpha1.InstallModeTypeOwnNamespace),
+		)
+	case 1:
+		switch watchNamespaces[0] {
+		case "":
+			return sets.New[string](string(v1alpha1.InstallModeTypeAllNamespaces))
+		case namespace:
+			return sets.New[string](string(v1alpha1.InstallModeTypeOwnNamespace))
+		default:
+			return sets.New[string](string(v1alpha1.InstallModeTypeSingleNamespace))
+		}
+	default:
+		return sets.New[string](string(v1alpha1.InstallModeTypeMultiNamespace))
+	}
+}
+
+// ValidateOperatorGroup returns an error if an operator supporting
+// operatorInstallModes cannot be installed with the desired install modes
+// in the namespace of the existing operator group og.
+func ValidateOperatorGroup(og v1.OperatorGroup, operatorInstallModes, desired sets.Set[string]) error {
+	ogSupported := PossibleInstallModes(og.Namespace, og.Status.Namespaces)
+
+	if operatorInstallModes.Intersection(ogSupported).Len() == 0 {
+		return fmt.Errorf("install modes supported by operator (%q) not compatible with install modes supported by existing operator group (%q)",
+			strings.Join(sets.List[string](operatorInstallModes), ","),
+			strings.Join(sets.List[string](ogSupported), ","),
+		)
+	}
+
+	if desired.Intersection(ogSupported).Len() == 0 {
+		return fmt.Errorf("install modes supported by desired watches (%q) not compatible with install modes supported by existing operator group (%q)",
+			strings.Join(sets.List[string](desired), ","),
+			strings.Join(sets.List[string](ogSupported), ","),
+		)
+	}
+	supported := operatorInstallModes.Intersection(desired)
+	if supported.Intersection(ogSupported).Len() == 0 {
+		return fmt.Errorf("install modes supported by operator and desired watches (%q) not compatible with install modes supported by existing operator group (%q)",
+			strings.Join(sets.List[string](supported), ","),
+			strings.Join(sets.List[string](ogSupported), ","),
+		)
+	}
+	return nil
+}
@@ -69,3 +69,17 @@ type ErrChannelNotFound struct {
 func (e ErrChannelNotFound) Error() string {
 	return fmt.Sprintf("channel %q does not exist for package %q", e.ChannelName, e.PackageName)
 }
+
+type ErrPackageNotFound struct {
+	PackageName string
+
+	// Catalog is set if the package was only looked up in one catalog.
+	Catalog string
+}
+
+func (e ErrPackageNotFound) Error() string {
+	if e.Catalog != "" {
+		return fmt.Sprintf("package %q not found in catalog %q", e.PackageName, e.Catalog)
+	}
+	return fmt.Sprintf("package %q not found", e.PackageName)
+}
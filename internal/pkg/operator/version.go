@@ -0,0 +1,109 @@
+package operator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// SemverRegexp matches a semver version, such as the one embedded in a CSV name.
+var SemverRegexp = regexp.MustCompile(`(?P<major>0|[1-9]\d*)\.(?P<minor>0|[1-9]\d*)\.(?P<patch>0|[1-9]\d*)(?:-(?P<prerelease>(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+(?P<buildmetadata>[0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?`) //nolint:lll
+
+// IsVersionConstraint returns true if v is a semver constraint, such as
+// ">=1.4 <2.0" or "~1.4", rather than a single exact version.
+func IsVersionConstraint(v string) bool {
+	if v == "" {
+		return false
+	}
+	if _, err := semver.StrictNewVersion(strings.TrimPrefix(v, "v")); err == nil {
+		return false
+	}
+	_, err := semver.NewConstraint(v)
+	return err == nil
+}
+
+// CheckVersionConstraint returns an error if version does not satisfy constraint.
+func CheckVersionConstraint(constraint, version string) error {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return fmt.Errorf("parse version constraint %q: %v", constraint, err)
+	}
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return fmt.Errorf("parse version %q: %v", version, err)
+	}
+	if !c.Check(v) {
+		return fmt.Errorf("version %q does not satisfy constraint %q", version, constraint)
+	}
+	return nil
+}
+
+// StartingCSV returns the name of the channel entry for desiredVersion, which
+// may be an exact version or a version constraint.
+func (pc PackageChannel) StartingCSV(desiredVersion string) (string, error) {
+	// A listing of all channel entries was added in a recent version of the packagemanifests API.
+	// If the length of the list is 0, that means we're on an older version of the API, so we'll fall
+	// back to the previous behavior of just guessing a CSV name.
+	//
+	// With the updated packagemanifests API, we can iterate the list of entries and find the
+	// startingCSV, and error out if the specified version is not found.
+	if len(pc.Entries) == 0 {
+		if IsVersionConstraint(desiredVersion) {
+			return "", fmt.Errorf("cannot resolve version constraint %q: channel %q does not list its entries", desiredVersion, pc.Name)
+		}
+		// Use the CSV name of the channel head as a template to guess the CSV name based on
+		// the desired version.
+		return guessStartingCSV(pc.CurrentCSV, desiredVersion)
+	}
+	for _, entry := range pc.Entries {
+		if desiredVersion == entry.Version {
+			return entry.Name, nil
+		}
+	}
+	if IsVersionConstraint(desiredVersion) {
+		return pc.resolveVersionConstraint(desiredVersion)
+	}
+	return "", fmt.Errorf("version %q not found in channel %q", desiredVersion, pc.Name)
+}
+
+// guessStartingCSV finds the first semver version string in csvNameExample, and replaces all
+// occurrences with desiredVersion, trimming any "v" prefix from desiredVersion prior to making the
+// replacements. If csvNameExample does not contain a semver version string, guessStartingCSV
+// returns an error.
+func guessStartingCSV(csvNameExample, desiredVersion string) (string, error) {
+	exampleVersion := SemverRegexp.FindString(csvNameExample)
+	if exampleVersion == "" {
+		return "", fmt.Errorf("could not locate semver version in channel head CSV name %q", csvNameExample)
+	}
+	desiredVersion = strings.TrimPrefix(desiredVersion, "v")
+	return strings.ReplaceAll(csvNameExample, exampleVersion, desiredVersion), nil
+}
+
+// resolveVersionConstraint returns the name of the channel entry with the
+// highest version that satisfies the constraint.
+func (pc PackageChannel) resolveVersionConstraint(constraint string) (string, error) {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return "", fmt.Errorf("parse version constraint %q: %v", constraint, err)
+	}
+
+	var (
+		name    string
+		highest *semver.Version
+	)
+	for _, entry := range pc.Entries {
+		v, err := semver.NewVersion(entry.Version)
+		if err != nil {
+			continue
+		}
+		if c.Check(v) && (highest == nil || v.GreaterThan(highest)) {
+			name, highest = entry.Name, v
+		}
+	}
+	if highest == nil {
+		return "", fmt.Errorf("no version in channel %q satisfies constraint %q", pc.Name, constraint)
+	}
+	return name, nil
+}
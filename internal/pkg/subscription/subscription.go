@@ -9,6 +9,10 @@ import (
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 )
 
+// VersionConstraintAnnotation records the semver constraint a subscription
+// was installed with, so that upgrades can be checked against it.
+const VersionConstraintAnnotation = "operators.operatorframework.io/kubectl-operator-version-constraint"
+
 type Option func(*v1alpha1.Subscription)
 
 func InstallPlanApproval(v v1alpha1.Approval) Option {
@@ -23,6 +27,15 @@ func StartingCSV(v string) Option {
 	}
 }
 
+func VersionConstraint(v string) Option {
+	return func(s *v1alpha1.Subscription) {
+		if s.Annotations == nil {
+			s.Annotations = map[string]string{}
+		}
+		s.Annotations[VersionConstraintAnnotation] = v
+	}
+}
+
 func Build(key types.NamespacedName, channel string, source types.NamespacedName, opts ...Option) *v1alpha1.Subscription {
 	s := &v1alpha1.Subscription{
 		ObjectMeta: metav1.ObjectMeta{
@@ -44,26 +57,37 @@ func Build(key types.NamespacedName, channel string, source types.NamespacedName
 
 const defaultApproval = v1alpha1.ApprovalManual
 
-type ApprovalValue struct {
-	v1alpha1.Approval
-}
+// ApprovalValue is a flag value for an approval, bound with
+// (*ApprovalValue)(&approval).
+type ApprovalValue v1alpha1.Approval
 
 func (a *ApprovalValue) Set(str string) error {
 	switch v := v1alpha1.Approval(str); v {
 	case v1alpha1.ApprovalAutomatic, v1alpha1.ApprovalManual:
-		a.Approval = v
+		*a = ApprovalValue(v)
 		return nil
 	}
 	return fmt.Errorf("invalid approval value %q", str)
 }
 
+// String reports an unset approval as the default, without setting it, so
+// that an approval that was not given on the command line stays empty.
 func (a *ApprovalValue) String() string {
-	if a.Approval == "" {
-		a.Approval = defaultApproval
+	if *a == "" {
+		return string(defaultApproval)
 	}
-	return string(a.Approval)
+	return string(*a)
 }
 
 func (a ApprovalValue) Type() string {
 	return "ApprovalValue"
 }
+
+// CSVName returns the name of the CSV installed by the subscription, or of the
+// CSV it is installing if none is installed yet.
+func CSVName(s *v1alpha1.Subscription) string {
+	if s.Status.InstalledCSV != "" {
+		return s.Status.InstalledCSV
+	}
+	return s.Status.CurrentCSV
+}
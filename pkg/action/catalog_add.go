@@ -0,0 +1,238 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/containerd/containerd/archive/compression"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/platforms"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-registry/pkg/image"
+	"github.com/operator-framework/operator-registry/pkg/image/containerdregistry"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/catalogsource"
+	"github.com/operator-framework/kubectl-operator/internal/pkg/cluster"
+)
+
+const (
+	alphaDisplayNameLabel = "alpha.operators.operatorframework.io.index.display-name.v1"
+	alphaPublisherLabel   = "alpha.operators.operatorframework.io.index.publisher.v1"
+)
+
+// CatalogAddOptions are the settings of a CatalogAdd.
+type CatalogAddOptions struct {
+	CatalogSourceName string
+	IndexImage        string
+
+	// DisplayName and Publisher default to the labels of the index image.
+	DisplayName string
+	Publisher   string
+
+	// CleanupTimeout bounds the deletion of a catalog source that did not
+	// become ready.
+	CleanupTimeout time.Duration
+}
+
+// CatalogAdd applies a catalog source for an index image and waits for it to
+// become ready.
+type CatalogAdd struct {
+	config *Configuration
+
+	CatalogAddOptions
+
+	// Events, if set, receives the progress of the add. Logf is called with
+	// the message of each event that has one.
+	Events          EventSink
+	Logf            func(string, ...interface{})
+	RegistryOptions []containerdregistry.RegistryOption
+
+	registry *containerdregistry.Registry
+}
+
+// Phases and conditions reported in the events of CatalogAdd.
+const (
+	CatalogAddPhaseImage         = "InspectImage"
+	CatalogAddPhaseCatalogSource = "CatalogSource"
+
+	ConditionCatalogSourceReady = "READY"
+)
+
+func NewCatalogAdd(cfg *Configuration) *CatalogAdd {
+	return &CatalogAdd{
+		config: cfg,
+		CatalogAddOptions: CatalogAddOptions{
+			CleanupTimeout: time.Minute,
+		},
+		Logf: func(string, ...interface{}) {},
+	}
+}
+
+func (a *CatalogAdd) Run(ctx context.Context) (*v1alpha1.CatalogSource, error) {
+	cs, err := a.run(ctx)
+	if err != nil {
+		a.emit(Event{Type: EventError, Err: err})
+	}
+	return cs, err
+}
+
+func (a *CatalogAdd) emit(e Event) {
+	emitEvent(a.Events, a.Logf, e)
+}
+
+func (a *CatalogAdd) run(ctx context.Context) (*v1alpha1.CatalogSource, error) {
+	var err error
+	a.registry, err = containerdregistry.NewRegistry(a.RegistryOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := a.registry.Destroy(); err != nil {
+			a.emit(Event{Type: EventError, Err: err, Message: fmt.Sprintf("registry cleanup: %v", err)})
+		}
+	}()
+
+	csKey := types.NamespacedName{
+		Namespace: a.config.Namespace,
+		Name:      a.CatalogSourceName,
+	}
+
+	a.emit(Event{Type: EventPhaseStarted, Phase: CatalogAddPhaseImage})
+	labels, err := a.labelsFor(ctx, a.IndexImage)
+	if err != nil {
+		return nil, fmt.Errorf("get image labels: %w", err)
+	}
+
+	a.setDefaults(labels)
+	a.emit(Event{Type: EventPhaseCompleted, Phase: CatalogAddPhaseImage})
+
+	opts := []catalogsource.Option{
+		catalogsource.DisplayName(a.DisplayName),
+		catalogsource.Publisher(a.Publisher),
+		catalogsource.Image(a.IndexImage),
+	}
+
+	a.emit(Event{Type: EventPhaseStarted, Phase: CatalogAddPhaseCatalogSource})
+
+	// Only a catalog source created by this run is cleaned up on failure;
+	// an existing one is left as applied.
+	created := false
+	if err := a.config.Client.Get(ctx, csKey, &v1alpha1.CatalogSource{}); apierrors.IsNotFound(err) {
+		created = true
+	} else if err != nil {
+		return nil, fmt.Errorf("get catalogsource: %w", err)
+	}
+
+	cs := catalogsource.Build(csKey, opts...)
+	if err := cluster.Apply(ctx, a.config.Client, a.config.Scheme, cs); err != nil {
+		return nil, fmt.Errorf("apply catalogsource: %w", err)
+	}
+	csRef := ReferenceTo(v1alpha1.CatalogSourceKind, cs)
+	applied := EventObjectUpdated
+	if created {
+		applied = EventObjectCreated
+	}
+	a.emit(Event{Type: applied, Phase: CatalogAddPhaseCatalogSource, Object: csRef})
+
+	a.emit(Event{Type: EventWaiting, Phase: CatalogAddPhaseCatalogSource, Object: csRef, Condition: ConditionCatalogSourceReady})
+	if err := a.waitForCatalogSourceReady(ctx, cs); err != nil {
+		if created {
+			defer a.cleanup(ctx, cs)
+		}
+		return nil, err
+	}
+	a.emit(Event{Type: EventConditionMet, Phase: CatalogAddPhaseCatalogSource, Object: csRef, Condition: ConditionCatalogSourceReady})
+	a.emit(Event{Type: EventPhaseCompleted, Phase: CatalogAddPhaseCatalogSource})
+
+	return cs, nil
+}
+
+func (a *CatalogAdd) labelsFor(ctx context.Context, indexImage string) (map[string]string, error) {
+	ref := image.SimpleReference(indexImage)
+	if err := a.registry.Pull(ctx, ref); err != nil {
+		return nil, fmt.Errorf("pull image: %w", err)
+	}
+
+	ctx = namespaces.WithNamespace(ctx, namespaces.Default)
+	img, err := a.registry.Images().Get(ctx, ref.String())
+	if err != nil {
+		return nil, fmt.Errorf("get image from local registry: %w", err)
+	}
+
+	manifest, err := images.Manifest(ctx, a.registry.Content(), img.Target, platforms.All)
+	if err != nil {
+		return nil, fmt.Errorf("resolve image manifest: %w", err)
+	}
+
+	ra, err := a.registry.Content().ReaderAt(ctx, manifest.Config)
+	if err != nil {
+		return nil, fmt.Errorf("get image reader: %w", err)
+	}
+	defer ra.Close()
+
+	decompressed, err := compression.DecompressStream(io.NewSectionReader(ra, 0, ra.Size()))
+	if err != nil {
+		return nil, fmt.Errorf("decompress image data: %w", err)
+	}
+	var imageMeta ocispec.Image
+	dec := json.NewDecoder(decompressed)
+	if err := dec.Decode(&imageMeta); err != nil {
+		return nil, fmt.Errorf("decode image metadata: %w", err)
+	}
+	return imageMeta.Config.Labels, nil
+}
+
+func (a *CatalogAdd) setDefaults(labels map[string]string) {
+	if a.DisplayName == "" {
+		if v, ok := labels[alphaDisplayNameLabel]; ok {
+			a.DisplayName = v
+		}
+	}
+	if a.Publisher == "" {
+		if v, ok := labels[alphaPublisherLabel]; ok {
+			a.Publisher = v
+		}
+	}
+}
+
+func (a *CatalogAdd) waitForCatalogSourceReady(ctx context.Context, cs *v1alpha1.CatalogSource) error {
+	csKey := client.ObjectKeyFromObject(cs)
+	if err := wait.PollUntilContextCancel(ctx, time.Millisecond*250, true, func(conditionCtx context.Context) (bool, error) {
+		if err := a.config.Client.Get(conditionCtx, csKey, cs); err != nil {
+			return false, err
+		}
+		if cs.Status.GRPCConnectionState != nil {
+			if cs.Status.GRPCConnectionState.LastObservedState == "READY" {
+				return true, nil
+			}
+		}
+		return false, nil
+	}); err != nil {
+		return fmt.Errorf("catalogsource connection not ready: %w", err)
+	}
+	return nil
+}
+
+// cleanup deletes the catalog source even if ctx is already done, since the
+// failure may have been its cancellation.
+func (a *CatalogAdd) cleanup(ctx context.Context, cs *v1alpha1.CatalogSource) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), a.CleanupTimeout)
+	defer cancel()
+	csRef := ReferenceTo(v1alpha1.CatalogSourceKind, cs)
+	if err := a.config.Client.Delete(ctx, cs); err != nil && !apierrors.IsNotFound(err) {
+		a.emit(Event{Type: EventError, Object: csRef, Err: err, Message: fmt.Sprintf("delete catalogsource %q: %v", cs.Name, err)})
+	} else if err == nil {
+		a.emit(Event{Type: EventObjectDeleted, Object: csRef})
+	}
+}
@@ -0,0 +1,32 @@
+package action
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+// CatalogList lists the catalog sources in the configured namespace.
+type CatalogList struct {
+	config *Configuration
+}
+
+func NewCatalogList(cfg *Configuration) *CatalogList {
+	return &CatalogList{cfg}
+}
+
+func (l *CatalogList) Run(ctx context.Context) ([]v1alpha1.CatalogSource, error) {
+	css := v1alpha1.CatalogSourceList{}
+	if err := l.config.Client.List(ctx, &css, client.InNamespace(l.config.Namespace)); err != nil {
+		return nil, err
+	}
+	return css.Items, nil
+}
+
+// Watch reports the catalog sources Run lists, and then every change to them
+// until ctx is done or handler returns an error.
+func (l *CatalogList) Watch(ctx context.Context, handler func(WatchEvent) error) error {
+	return l.config.Watch(ctx, &v1alpha1.CatalogSourceList{}, handler, client.InNamespace(l.config.Namespace))
+}
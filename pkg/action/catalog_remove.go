@@ -0,0 +1,38 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/cluster"
+)
+
+// CatalogRemoveOptions are the settings of a CatalogRemove.
+type CatalogRemoveOptions struct {
+	CatalogName string
+}
+
+// CatalogRemove deletes a catalog source and waits until it is gone.
+type CatalogRemove struct {
+	config *Configuration
+
+	CatalogRemoveOptions
+}
+
+func NewCatalogRemove(cfg *Configuration) *CatalogRemove {
+	return &CatalogRemove{
+		config: cfg,
+	}
+}
+
+func (r *CatalogRemove) Run(ctx context.Context) error {
+	cs := v1alpha1.CatalogSource{}
+	cs.SetNamespace(r.config.Namespace)
+	cs.SetName(r.CatalogName)
+	if err := r.config.Client.Delete(ctx, &cs); err != nil {
+		return fmt.Errorf("delete catalogsource %q: %w", cs.Name, err)
+	}
+	return cluster.WaitForDeletion(ctx, r.config.Client, &cs)
+}
@@ -2,19 +2,39 @@ package action
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/spf13/pflag"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	v1 "github.com/operator-framework/api/pkg/operators/v1"
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 	olmv1 "github.com/operator-framework/operator-controller/api/v1alpha1"
 	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/cluster"
 )
 
+// FieldManager is the field manager kubectl-operator uses when it creates or
+// applies objects.
+const FieldManager = cluster.FieldManager
+
 func NewScheme() (*runtime.Scheme, error) {
 	sch := runtime.NewScheme()
 	for _, f := range []func(*runtime.Scheme) error{
@@ -23,6 +43,8 @@ func NewScheme() (*runtime.Scheme, error) {
 		v1.AddToScheme,
 		apiextensionsv1.AddToScheme,
 		olmv1.AddToScheme,
+		appsv1.AddToScheme,
+		corev1.AddToScheme,
 	} {
 		if err := f(sch); err != nil {
 			return nil, err
@@ -36,7 +58,30 @@ type Configuration struct {
 	Namespace string
 	Scheme    *runtime.Scheme
 
-	overrides *clientcmd.ConfigOverrides
+	// Context is the name of the kubeconfig context the configuration was
+	// loaded from.
+	Context string
+
+	// RESTConfig is the config used to build Client. It is available for
+	// actions that need a typed clientset, for example to read pod logs.
+	RESTConfig *rest.Config
+
+	// QPS and Burst limit the rate of requests to the API server. Zero
+	// values use the client-go defaults.
+	QPS   float32
+	Burst int
+
+	// RequestTimeout bounds each request to the API server. Zero means no
+	// per-request timeout; the overall operation is still bounded by the
+	// command's context.
+	RequestTimeout time.Duration
+
+	// RequestLog, if set, receives one line per API request with its verb,
+	// resource, response status and latency.
+	RequestLog io.Writer
+
+	overrides   *clientcmd.ConfigOverrides
+	logRequests bool
 }
 
 func (c *Configuration) BindFlags(fs *pflag.FlagSet) {
@@ -52,7 +97,21 @@ func (c *Configuration) BindFlags(fs *pflag.FlagSet) {
 				Description: "If present, namespace scope for this CLI request",
 			},
 		},
+		AuthOverrideFlags: clientcmd.AuthOverrideFlags{
+			Impersonate: clientcmd.FlagInfo{
+				LongName:    "as",
+				Description: "Username to impersonate for the operation",
+			},
+			ImpersonateGroups: clientcmd.FlagInfo{
+				LongName:    "as-group",
+				Description: "Group to impersonate for the operation, this flag can be repeated to specify multiple groups",
+			},
+		},
 	})
+	fs.Float32Var(&c.QPS, "qps", 0, "Maximum queries per second to the API server (0 uses the client default)")
+	fs.IntVar(&c.Burst, "burst", 0, "Maximum burst of queries to the API server (0 uses the client default)")
+	fs.DurationVar(&c.RequestTimeout, "request-timeout", 0, "The amount of time to wait for a single API server request (0 means no timeout)")
+	fs.BoolVar(&c.logRequests, "log-requests", false, "Log the verb, resource, status and latency of each API server request to stderr")
 }
 
 func (c *Configuration) Load() error {
@@ -64,6 +123,60 @@ func (c *Configuration) Load() error {
 	if err != nil {
 		return err
 	}
+	return c.load(mergedConfig)
+}
+
+// LoadContexts loads a configuration for each of the named kubeconfig
+// contexts, or for every context in the kubeconfig if names is empty. The
+// flags bound by BindFlags, such as the namespace, apply to each of them.
+//
+// A context that is missing or fails to load does not stop the others: the
+// configurations that loaded are returned in context order, and the errors of
+// the others by context name. The returned error is only set if the
+// kubeconfig itself cannot be loaded.
+func (c *Configuration) LoadContexts(names []string) ([]*Configuration, map[string]error, error) {
+	if c.overrides == nil {
+		c.overrides = &clientcmd.ConfigOverrides{}
+	}
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	mergedConfig, err := loadingRules.Load()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(names) == 0 {
+		for name := range mergedConfig.Contexts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	cfgs := make([]*Configuration, 0, len(names))
+	errs := map[string]error{}
+	for _, name := range names {
+		if _, ok := mergedConfig.Contexts[name]; !ok {
+			errs[name] = fmt.Errorf("context %q not found in kubeconfig", name)
+			continue
+		}
+		overrides := *c.overrides
+		overrides.CurrentContext = name
+		cfg := &Configuration{
+			QPS:            c.QPS,
+			Burst:          c.Burst,
+			RequestTimeout: c.RequestTimeout,
+			RequestLog:     c.RequestLog,
+			overrides:      &overrides,
+			logRequests:    c.logRequests,
+		}
+		if err := cfg.load(mergedConfig); err != nil {
+			errs[name] = fmt.Errorf("load context %q: %v", name, err)
+			continue
+		}
+		cfgs = append(cfgs, cfg)
+	}
+	return cfgs, errs, nil
+}
+
+func (c *Configuration) load(mergedConfig *clientcmdapi.Config) error {
 	cfg := clientcmd.NewDefaultClientConfig(*mergedConfig, c.overrides)
 	cc, err := cfg.ClientConfig()
 	if err != nil {
@@ -75,11 +188,32 @@ func (c *Configuration) Load() error {
 		return err
 	}
 
+	kubeContext := c.overrides.CurrentContext
+	if kubeContext == "" {
+		kubeContext = mergedConfig.CurrentContext
+	}
+
+	if c.QPS != 0 {
+		cc.QPS = c.QPS
+	}
+	if c.Burst != 0 {
+		cc.Burst = c.Burst
+	}
+	if c.RequestTimeout != 0 {
+		cc.Timeout = c.RequestTimeout
+	}
+	if c.logRequests && c.RequestLog == nil {
+		c.RequestLog = os.Stderr
+	}
+	if c.RequestLog != nil {
+		cc.Wrap(newRequestLogger(c.RequestLog, kubeContext))
+	}
+
 	sch, err := NewScheme()
 	if err != nil {
 		return err
 	}
-	cl, err := client.New(cc, client.Options{
+	cl, err := client.NewWithWatch(cc, client.Options{
 		Scheme: sch,
 	})
 	if err != nil {
@@ -89,15 +223,86 @@ func (c *Configuration) Load() error {
 	c.Scheme = sch
 	c.Client = &operatorClient{cl}
 	c.Namespace = ns
+	c.RESTConfig = cc
+	c.Context = kubeContext
 
 	return nil
 }
 
 type operatorClient struct {
-	client.Client
+	client.WithWatch
 }
 
 func (c *operatorClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
-	opts = append(opts, client.FieldOwner("kubectl-operator"))
-	return c.Client.Create(ctx, obj, opts...)
+	opts = append(opts, client.FieldOwner(FieldManager))
+	return c.WithWatch.Create(ctx, obj, opts...)
+}
+
+// requestLogMu serializes request log lines from concurrent clients, such as
+// the per-context clients of a multi-cluster command sharing stderr.
+var requestLogMu sync.Mutex
+
+// newRequestLogger returns a transport wrapper that writes a line to w for
+// each request, prefixed with the kubeconfig context.
+func newRequestLogger(w io.Writer, kubeContext string) func(http.RoundTripper) http.RoundTripper {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &requestLogger{rt: rt, w: w, context: kubeContext}
+	}
+}
+
+type requestLogger struct {
+	rt      http.RoundTripper
+	w       io.Writer
+	context string
+}
+
+func (l *requestLogger) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := l.rt.RoundTrip(req)
+	latency := time.Since(start).Round(time.Millisecond)
+
+	var status string
+	if err != nil {
+		status = fmt.Sprintf("error: %v", err)
+	} else {
+		status = resp.Status
+	}
+
+	requestLogMu.Lock()
+	defer requestLogMu.Unlock()
+	fmt.Fprintf(l.w, "[%s] %s %s %s\n", l.context, describeRequest(req), status, latency)
+	return resp, err
+}
+
+// requestInfoFactory parses requests the way the API server does, to tell
+// the verb of a request, such as list or watch, from its method and URL.
+var requestInfoFactory = &request.RequestInfoFactory{
+	APIPrefixes:          sets.NewString("api", "apis"),
+	GrouplessAPIPrefixes: sets.NewString("api"),
+}
+
+// describeRequest describes a request by its API verb and resource, for
+// example "list deployments.apps -n default" or "get namespaces/default".
+// Requests that are not for a resource, such as discovery, are described by
+// their verb and path.
+func describeRequest(req *http.Request) string {
+	info, err := requestInfoFactory.NewRequestInfo(req)
+	if err != nil || !info.IsResourceRequest {
+		return fmt.Sprintf("%s %s", strings.ToLower(req.Method), req.URL.Path)
+	}
+
+	resource := info.Resource
+	if info.APIGroup != "" {
+		resource += "." + info.APIGroup
+	}
+	if info.Name != "" {
+		resource += "/" + info.Name
+	}
+	if info.Subresource != "" {
+		resource += "/" + info.Subresource
+	}
+	if info.Namespace != "" && info.Resource != "namespaces" {
+		resource += " -n " + info.Namespace
+	}
+	return info.Verb + " " + resource
 }
@@ -0,0 +1,194 @@
+package action_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/rest"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+const multiClusterKubeconfig = `apiVersion: v1
+kind: Config
+current-context: staging
+clusters:
+- name: staging
+  cluster:
+    server: https://staging.example.com:6443
+- name: production
+  cluster:
+    server: https://production.example.com:6443
+users:
+- name: admin
+  user:
+    token: secret
+contexts:
+- name: staging
+  context:
+    cluster: staging
+    user: admin
+    namespace: operators
+- name: production
+  context:
+    cluster: production
+    user: admin
+`
+
+var _ = Describe("Configuration", func() {
+	var (
+		cfg                action.Configuration
+		dir, oldKubeconfig string
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "kubectl-operator-config-")
+		Expect(err).To(BeNil())
+
+		kubeconfig := filepath.Join(dir, "config")
+		Expect(os.WriteFile(kubeconfig, []byte(multiClusterKubeconfig), 0600)).To(Succeed())
+		oldKubeconfig = os.Getenv("KUBECONFIG")
+		Expect(os.Setenv("KUBECONFIG", kubeconfig)).To(Succeed())
+
+		cfg = action.Configuration{}
+	})
+
+	AfterEach(func() {
+		Expect(os.Setenv("KUBECONFIG", oldKubeconfig)).To(Succeed())
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("should record the current context", func() {
+		Expect(cfg.Load()).To(Succeed())
+		Expect(cfg.Context).To(Equal("staging"))
+		Expect(cfg.Namespace).To(Equal("operators"))
+	})
+
+	It("should load every context in name order", func() {
+		cfgs, errs, err := cfg.LoadContexts(nil)
+		Expect(err).To(BeNil())
+		Expect(errs).To(BeEmpty())
+		Expect(cfgs).To(HaveLen(2))
+		Expect(cfgs[0].Context).To(Equal("production"))
+		Expect(cfgs[0].RESTConfig.Host).To(Equal("https://production.example.com:6443"))
+		Expect(cfgs[0].Namespace).To(Equal("default"))
+		Expect(cfgs[1].Context).To(Equal("staging"))
+		Expect(cfgs[1].Namespace).To(Equal("operators"))
+	})
+
+	It("should apply flag overrides to each context", func() {
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		cfg.BindFlags(fs)
+		Expect(fs.Parse([]string{"--namespace", "team-a"})).To(Succeed())
+
+		cfgs, errs, err := cfg.LoadContexts([]string{"staging", "production"})
+		Expect(err).To(BeNil())
+		Expect(errs).To(BeEmpty())
+		Expect(cfgs).To(HaveLen(2))
+		Expect(cfgs[0].Context).To(Equal("staging"))
+		Expect(cfgs[0].Namespace).To(Equal("team-a"))
+		Expect(cfgs[1].Context).To(Equal("production"))
+		Expect(cfgs[1].Namespace).To(Equal("team-a"))
+	})
+
+	It("should report an unknown context without failing the others", func() {
+		cfgs, errs, err := cfg.LoadContexts([]string{"staging", "missing"})
+		Expect(err).To(BeNil())
+		Expect(cfgs).To(HaveLen(1))
+		Expect(cfgs[0].Context).To(Equal("staging"))
+		Expect(errs).To(HaveLen(1))
+		Expect(errs["missing"]).To(MatchError(`context "missing" not found in kubeconfig`))
+	})
+
+	It("should report a context that fails to load without failing the others", func() {
+		kubeconfig := filepath.Join(dir, "stale")
+		Expect(os.WriteFile(kubeconfig, []byte(multiClusterKubeconfig+`- name: stale
+  context:
+    cluster: deleted
+    user: admin
+`), 0600)).To(Succeed())
+		Expect(os.Setenv("KUBECONFIG", kubeconfig)).To(Succeed())
+
+		cfgs, errs, err := cfg.LoadContexts(nil)
+		Expect(err).To(BeNil())
+		Expect(cfgs).To(HaveLen(2))
+		Expect(errs).To(HaveKey("stale"))
+		Expect(errs["stale"]).To(MatchError(ContainSubstring(`load context "stale"`)))
+	})
+
+	It("should apply client tuning and impersonation flags to each context", func() {
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		cfg.BindFlags(fs)
+		Expect(fs.Parse([]string{
+			"--qps", "50", "--burst", "100", "--request-timeout", "30s",
+			"--as", "tenant", "--as-group", "team-a", "--as-group", "team-b",
+		})).To(Succeed())
+
+		cfgs, _, err := cfg.LoadContexts(nil)
+		Expect(err).To(BeNil())
+		Expect(cfgs).To(HaveLen(2))
+		for _, c := range cfgs {
+			Expect(c.RESTConfig.QPS).To(Equal(float32(50)))
+			Expect(c.RESTConfig.Burst).To(Equal(100))
+			Expect(c.RESTConfig.Timeout).To(Equal(30 * time.Second))
+			Expect(c.RESTConfig.Impersonate.UserName).To(Equal("tenant"))
+			Expect(c.RESTConfig.Impersonate.Groups).To(Equal([]string{"team-a", "team-b"}))
+		}
+	})
+
+	It("should log each request when a request log is set", func() {
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+		kubeconfig := filepath.Join(dir, "local")
+		Expect(os.WriteFile(kubeconfig, []byte(fmt.Sprintf(`apiVersion: v1
+kind: Config
+current-context: local
+clusters:
+- name: local
+  cluster:
+    server: %s
+contexts:
+- name: local
+  context:
+    cluster: local
+`, server.URL)), 0600)).To(Succeed())
+		Expect(os.Setenv("KUBECONFIG", kubeconfig)).To(Succeed())
+
+		var buf bytes.Buffer
+		cfg.RequestLog = &buf
+		Expect(cfg.Load()).To(Succeed())
+
+		hc, err := rest.HTTPClientFor(cfg.RESTConfig)
+		Expect(err).To(BeNil())
+		for _, path := range []string{
+			"/api/v1/namespaces/default",
+			"/apis/apps/v1/namespaces/default/deployments",
+			"/apis/apps/v1/namespaces/default/deployments?watch=true",
+			"/apis/apps/v1/namespaces/default/deployments/etcd-operator/status",
+			"/version",
+		} {
+			resp, err := hc.Get(server.URL + path)
+			Expect(err).To(BeNil())
+			Expect(resp.Body.Close()).To(Succeed())
+		}
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		Expect(lines).To(HaveLen(5))
+		Expect(lines[0]).To(MatchRegexp(`^\[local\] get namespaces/default 404 Not Found \d+m?s$`))
+		Expect(lines[1]).To(MatchRegexp(`^\[local\] list deployments.apps -n default 404 Not Found \d+m?s$`))
+		Expect(lines[2]).To(MatchRegexp(`^\[local\] watch deployments.apps -n default 404 Not Found \d+m?s$`))
+		Expect(lines[3]).To(MatchRegexp(`^\[local\] get deployments.apps/etcd-operator/status -n default 404 Not Found \d+m?s$`))
+		Expect(lines[4]).To(MatchRegexp(`^\[local\] get /version 404 Not Found \d+m?s$`))
+	})
+})
@@ -0,0 +1,9 @@
+package action
+
+const (
+	csvKind = "ClusterServiceVersion"
+
+	// CSV annotations that packageserver surfaces in the CSV description.
+	capabilitiesAnnotation = "capabilities"
+	descriptionAnnotation  = "description"
+)
@@ -0,0 +1,18 @@
+package action
+
+import (
+	"github.com/operator-framework/kubectl-operator/internal/pkg/operator"
+)
+
+// ErrPackageNotFound is returned when no catalog serves the requested
+// package, or when no subscription exists for the package to upgrade or
+// uninstall.
+type ErrPackageNotFound = operator.ErrPackageNotFound
+
+// ErrChannelNotFound is returned when the requested channel does not exist in
+// the package.
+type ErrChannelNotFound = operator.ErrChannelNotFound
+
+// ErrNoDefaultChannel is returned when no channel is requested and the
+// package does not have a default channel.
+type ErrNoDefaultChannel = operator.ErrNoDefaultChannel
@@ -0,0 +1,89 @@
+package action
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EventType is the kind of progress an action reports.
+type EventType string
+
+const (
+	// EventPhaseStarted and EventPhaseCompleted mark the boundaries of a step
+	// of an action, named by Event.Phase.
+	EventPhaseStarted   EventType = "PhaseStarted"
+	EventPhaseCompleted EventType = "PhaseCompleted"
+
+	// EventObjectCreated, EventObjectUpdated and EventObjectDeleted report a
+	// write to Event.Object.
+	EventObjectCreated EventType = "ObjectCreated"
+	EventObjectUpdated EventType = "ObjectUpdated"
+	EventObjectDeleted EventType = "ObjectDeleted"
+
+	// EventWaiting reports that the action is waiting for Event.Object to
+	// meet Event.Condition, and EventConditionMet that it did.
+	EventWaiting      EventType = "Waiting"
+	EventConditionMet EventType = "ConditionMet"
+
+	// EventInfo reports progress that has no more specific type, such as a
+	// value resolved from the request, in Event.Message.
+	EventInfo EventType = "Info"
+
+	// EventWarning reports something that does not stop the action but that
+	// the user should know about, such as an operand left behind.
+	EventWarning EventType = "Warning"
+
+	// EventError reports the error that stopped the action, or a failed
+	// cleanup step, in Event.Err.
+	EventError EventType = "Error"
+)
+
+// ObjectReference identifies the object an event is about.
+type ObjectReference struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// ReferenceTo returns a reference to obj with the given kind. The kind is
+// passed in because typed objects usually do not carry it.
+func ReferenceTo(kind string, obj client.Object) *ObjectReference {
+	return &ObjectReference{Kind: kind, Namespace: obj.GetNamespace(), Name: obj.GetName()}
+}
+
+// Event is a progress report from an action. Only the fields relevant to its
+// Type are set.
+type Event struct {
+	Type      EventType
+	Phase     string
+	Object    *ObjectReference
+	Condition string
+	Err       error
+
+	// Message describes the event for people. It is empty for events that
+	// are only of interest to programs, such as phase boundaries.
+	Message string
+}
+
+// EventSink receives the progress events of an action. Events are delivered
+// synchronously from the goroutine running the action, so Event should not
+// block.
+type EventSink interface {
+	Event(Event)
+}
+
+// EventSinkFunc adapts a function to an EventSink.
+type EventSinkFunc func(Event)
+
+func (f EventSinkFunc) Event(e Event) {
+	f(e)
+}
+
+// LogEvents returns a sink that logs the message of each event with logf.
+// Actions implement their Logf field with it.
+func LogEvents(logf func(string, ...interface{})) EventSink {
+	return EventSinkFunc(func(e Event) {
+		if e.Message != "" {
+			logf("%s", e.Message)
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package action_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("LogEvents", func() {
+	It("should log the message of events that have one", func() {
+		logged := []string{}
+		sink := action.LogEvents(func(format string, args ...interface{}) {
+			logged = append(logged, fmt.Sprintf(format, args...))
+		})
+		sink.Event(action.Event{Type: action.EventPhaseStarted, Phase: "Subscription"})
+		sink.Event(action.Event{Type: action.EventObjectCreated, Message: `subscription "etcd" applied`})
+		sink.Event(action.Event{Type: action.EventWarning, Message: "100% done"})
+		Expect(logged).To(Equal([]string{`subscription "etcd" applied`, "100% done"}))
+	})
+})
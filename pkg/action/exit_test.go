@@ -0,0 +1,72 @@
+package action_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// exitFuncs are the functions, by import path, that end the process. A
+// library must return errors to its caller instead.
+var exitFuncs = map[string]func(string) bool{
+	"os":                         func(name string) bool { return name == "Exit" },
+	"log":                        func(name string) bool { return strings.HasPrefix(name, "Fatal") || strings.HasPrefix(name, "Panic") },
+	"k8s.io/klog":                func(name string) bool { return strings.HasPrefix(name, "Fatal") || strings.HasPrefix(name, "Exit") },
+	"k8s.io/klog/v2":             func(name string) bool { return strings.HasPrefix(name, "Fatal") || strings.HasPrefix(name, "Exit") },
+	"github.com/sirupsen/logrus": func(name string) bool { return strings.HasPrefix(name, "Fatal") || name == "Exit" },
+}
+
+var _ = Describe("Public API", func() {
+	It("should not exit the process", func() {
+		// pkg/action and the internal packages it is built on are used as a
+		// library, so none of their code may end the caller's process.
+		var calls []string
+		fset := token.NewFileSet()
+		for _, root := range []string{".", "../../internal/pkg"} {
+			Expect(filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+				if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+					return err
+				}
+				f, err := parser.ParseFile(fset, path, nil, 0)
+				if err != nil {
+					return err
+				}
+				imports := map[string]string{}
+				for _, imp := range f.Imports {
+					importPath, _ := strconv.Unquote(imp.Path.Value)
+					name := filepath.Base(importPath)
+					if strings.HasPrefix(name, "v") && strings.Contains(importPath, "/") {
+						name = filepath.Base(filepath.Dir(importPath))
+					}
+					if imp.Name != nil {
+						name = imp.Name.Name
+					}
+					imports[name] = importPath
+				}
+				ast.Inspect(f, func(n ast.Node) bool {
+					sel, ok := n.(*ast.SelectorExpr)
+					if !ok {
+						return true
+					}
+					pkg, ok := sel.X.(*ast.Ident)
+					if !ok || pkg.Obj != nil {
+						return true
+					}
+					if isExit, ok := exitFuncs[imports[pkg.Name]]; ok && isExit(sel.Sel.Name) {
+						calls = append(calls, fset.Position(sel.Pos()).String()+": "+pkg.Name+"."+sel.Sel.Name)
+					}
+					return true
+				})
+				return nil
+			})).To(Succeed())
+		}
+		Expect(calls).To(BeEmpty())
+	})
+})
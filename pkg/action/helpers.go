@@ -0,0 +1,55 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+// emitEvent reports e to sink, if one is set, and logs its message with logf.
+func emitEvent(sink EventSink, logf func(string, ...interface{}), e Event) {
+	if sink != nil {
+		sink.Event(e)
+	}
+	if logf != nil {
+		LogEvents(logf).Event(e)
+	}
+}
+
+func getCSV(ctx context.Context, cl client.Client, ip *v1alpha1.InstallPlan) (*v1alpha1.ClusterServiceVersion, error) {
+	ipKey := client.ObjectKeyFromObject(ip)
+	if err := wait.PollUntilContextCancel(ctx, time.Millisecond*250, true, func(conditionCtx context.Context) (bool, error) {
+		if err := cl.Get(conditionCtx, ipKey, ip); err != nil {
+			return false, err
+		}
+		if ip.Status.Phase == v1alpha1.InstallPlanPhaseComplete {
+			return true, nil
+		}
+		return false, nil
+	}); err != nil {
+		return nil, fmt.Errorf("waiting for operator installation to complete: %w", err)
+	}
+
+	csvKey := types.NamespacedName{
+		Namespace: ipKey.Namespace,
+	}
+	for _, s := range ip.Status.Plan {
+		if s.Resource.Kind == csvKind {
+			csvKey.Name = s.Resource.Name
+		}
+	}
+	if csvKey.Name == "" {
+		return nil, fmt.Errorf("could not find installed CSV in install plan")
+	}
+	csv := &v1alpha1.ClusterServiceVersion{}
+	if err := cl.Get(ctx, csvKey, csv); err != nil {
+		return nil, fmt.Errorf("get clusterserviceversion: %w", err)
+	}
+	return csv, nil
+}
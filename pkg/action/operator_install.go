@@ -0,0 +1,534 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/cluster"
+	"github.com/operator-framework/kubectl-operator/internal/pkg/operator"
+	"github.com/operator-framework/kubectl-operator/internal/pkg/subscription"
+)
+
+// InstallOptions are the settings of an OperatorInstall.
+type InstallOptions struct {
+	Package string
+
+	// Catalog selects the catalog source to install the package from. Its
+	// namespace may be left empty if the name is unique. Without it, the
+	// source of an existing subscription for the package is kept, and
+	// otherwise the package must be served by exactly one catalog.
+	Catalog NamespacedName
+
+	// Channel defaults to the package's default channel. Version is an exact
+	// version or a semver constraint, and defaults to the channel head.
+	Channel string
+	Version string
+
+	// Approval is the install plan approval of the subscription. If empty,
+	// a new subscription uses manual approval and an existing one keeps its
+	// own. Installs with a version constraint always use manual approval.
+	Approval            v1alpha1.Approval
+	WatchNamespaces     []string
+	CreateOperatorGroup bool
+
+	// ServiceAccount is the service account OLM uses to install the
+	// operator. It is set on the operator group created for the install, and
+	// must match the service account of an existing operator group.
+	ServiceAccount       string
+	CreateServiceAccount bool
+
+	// Update allows an existing subscription for the package to be patched
+	// to the requested channel, approval, source and version. Without it,
+	// installing over a subscription with different settings fails.
+	Update bool
+}
+
+// OperatorInstall installs a package by subscribing to it, and waits for the
+// operator's CSV to succeed.
+type OperatorInstall struct {
+	config *Configuration
+
+	InstallOptions
+
+	// Events, if set, receives the progress of the install. Logf is called
+	// with the message of each event that has one.
+	Events EventSink
+	Logf   func(string, ...interface{})
+}
+
+// Phases and conditions reported in the events of OperatorInstall.
+const (
+	InstallPhaseResolve      = "Resolve"
+	InstallPhaseSubscription = "Subscription"
+	InstallPhaseInstallPlan  = "InstallPlan"
+	InstallPhaseCSV          = "ClusterServiceVersion"
+
+	ConditionInstallPlanCreated  = "InstallPlanCreated"
+	ConditionInstallPlanComplete = "Complete"
+	ConditionCSVSucceeded        = "Succeeded"
+)
+
+func NewOperatorInstall(cfg *Configuration) *OperatorInstall {
+	return &OperatorInstall{
+		config: cfg,
+		Logf:   func(string, ...interface{}) {},
+	}
+}
+
+func (i *OperatorInstall) Run(ctx context.Context) (*v1alpha1.ClusterServiceVersion, error) {
+	csv, err := i.run(ctx)
+	if err != nil {
+		i.emit(Event{Type: EventError, Err: err})
+	}
+	return csv, err
+}
+
+func (i *OperatorInstall) emit(e Event) {
+	emitEvent(i.Events, i.Logf, e)
+}
+
+func (i *OperatorInstall) run(ctx context.Context) (*v1alpha1.ClusterServiceVersion, error) {
+	i.emit(Event{Type: EventPhaseStarted, Phase: InstallPhaseResolve})
+	existing, err := i.findSubscription(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-running an install without a catalog keeps the source of the
+	// existing subscription, which also chooses between the catalogs that
+	// serve the package.
+	catalog := i.Catalog.NamespacedName
+	if existing != nil && catalog.Name == "" && catalog.Namespace == "" {
+		catalog = types.NamespacedName{Namespace: existing.Spec.CatalogSourceNamespace, Name: existing.Spec.CatalogSource}
+	}
+	pm, err := cluster.GetPackageManifest(ctx, i.config.Client, i.config.Namespace, i.Package, catalog)
+	if err != nil {
+		return nil, fmt.Errorf("get package manifest: %w", err)
+	}
+
+	pc, err := pm.GetChannel(i.Channel)
+	if err != nil {
+		return nil, fmt.Errorf("get package channel: %w", err)
+	}
+
+	// Automatic approval would let OLM upgrade past the constraint, so
+	// constrained installs always require manual approval.
+	if operator.IsVersionConstraint(i.Version) {
+		if i.Approval != "" && i.Approval != v1alpha1.ApprovalManual {
+			i.emit(Event{
+				Type:    EventWarning,
+				Phase:   InstallPhaseResolve,
+				Message: fmt.Sprintf("version constraint %q requires %s approval; overriding approval %q", i.Version, v1alpha1.ApprovalManual, i.Approval),
+			})
+		}
+		i.Approval = v1alpha1.ApprovalManual
+	}
+	i.emit(Event{Type: EventPhaseCompleted, Phase: InstallPhaseResolve})
+
+	i.emit(Event{Type: EventPhaseStarted, Phase: InstallPhaseSubscription})
+	if existing != nil {
+		return i.adoptSubscription(ctx, existing, pm, pc)
+	}
+
+	// Everything that can refuse the install is checked before the first
+	// write, so that a refused install leaves nothing behind.
+	if i.CreateServiceAccount && i.ServiceAccount == "" {
+		return nil, fmt.Errorf("a service account name is required to create a service account")
+	}
+	og, targetNamespaces, err := i.checkOperatorGroup(ctx, pm, pc)
+	if err != nil {
+		return nil, err
+	}
+	sub, err := i.buildSubscription(types.NamespacedName{Namespace: i.config.Namespace, Name: i.Package}, pm, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	if i.CreateServiceAccount {
+		if err := i.ensureServiceAccount(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if og == nil {
+		if err := i.createOperatorGroup(ctx, targetNamespaces); err != nil {
+			return nil, err
+		}
+	}
+	if err := cluster.Apply(ctx, i.config.Client, i.config.Scheme, sub); err != nil {
+		return nil, fmt.Errorf("apply subscription: %w", err)
+	}
+	i.emit(Event{
+		Type:    EventObjectCreated,
+		Phase:   InstallPhaseSubscription,
+		Object:  ReferenceTo(v1alpha1.SubscriptionKind, sub),
+		Message: fmt.Sprintf("subscription %q applied", sub.Name),
+	})
+	i.emit(Event{Type: EventPhaseCompleted, Phase: InstallPhaseSubscription})
+
+	return i.waitForInitialInstall(ctx, sub)
+}
+
+// waitForInitialInstall approves the subscription's first install plan if
+// approval is manual, and returns the CSV it installs once it has succeeded.
+func (i *OperatorInstall) waitForInitialInstall(ctx context.Context, sub *v1alpha1.Subscription) (*v1alpha1.ClusterServiceVersion, error) {
+	i.emit(Event{Type: EventPhaseStarted, Phase: InstallPhaseInstallPlan})
+	subRef := ReferenceTo(v1alpha1.SubscriptionKind, sub)
+	i.emit(Event{Type: EventWaiting, Phase: InstallPhaseInstallPlan, Object: subRef, Condition: ConditionInstallPlanCreated})
+	ip, err := i.getInstallPlan(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+	i.emit(Event{Type: EventConditionMet, Phase: InstallPhaseInstallPlan, Object: subRef, Condition: ConditionInstallPlanCreated})
+
+	// We need to approve the initial install plan
+	ipRef := ReferenceTo(v1alpha1.InstallPlanKind, ip)
+	if sub.Spec.InstallPlanApproval == v1alpha1.ApprovalManual {
+		if err := cluster.ApproveInstallPlan(ctx, i.config.Client, ip); err != nil {
+			return nil, fmt.Errorf("approve install plan: %w", err)
+		}
+		i.emit(Event{Type: EventObjectUpdated, Phase: InstallPhaseInstallPlan, Object: ipRef})
+	}
+
+	i.emit(Event{Type: EventWaiting, Phase: InstallPhaseInstallPlan, Object: ipRef, Condition: ConditionInstallPlanComplete})
+	csv, err := getCSV(ctx, i.config.Client, ip)
+	if err != nil {
+		return nil, fmt.Errorf("get clusterserviceversion: %w", err)
+	}
+	i.emit(Event{Type: EventConditionMet, Phase: InstallPhaseInstallPlan, Object: ipRef, Condition: ConditionInstallPlanComplete})
+	i.emit(Event{Type: EventPhaseCompleted, Phase: InstallPhaseInstallPlan})
+
+	i.emit(Event{Type: EventPhaseStarted, Phase: InstallPhaseCSV})
+	csvRef := ReferenceTo(csvKind, csv)
+	i.emit(Event{Type: EventWaiting, Phase: InstallPhaseCSV, Object: csvRef, Condition: ConditionCSVSucceeded})
+	if err := i.waitForCSVSucceeded(ctx, csv); err != nil {
+		return nil, err
+	}
+	i.emit(Event{Type: EventConditionMet, Phase: InstallPhaseCSV, Object: csvRef, Condition: ConditionCSVSucceeded})
+	i.emit(Event{Type: EventPhaseCompleted, Phase: InstallPhaseCSV})
+	return csv, nil
+}
+
+// terminalCSVFailures are the reasons for a failed CSV that OLM does not
+// retry without the user changing something. OLM also fails CSVs on the way
+// to succeeding, for instance while the operator's deployment rolls out.
+var terminalCSVFailures = sets.New(
+	v1alpha1.CSVReasonComponentFailedNoRetry,
+	v1alpha1.CSVReasonInvalidStrategy,
+	v1alpha1.CSVReasonInvalidInstallModes,
+	v1alpha1.CSVReasonUnsupportedOperatorGroup,
+	v1alpha1.CSVReasonNoOperatorGroup,
+	v1alpha1.CSVReasonTooManyOperatorGroups,
+	v1alpha1.CSVReasonInterOperatorGroupOwnerConflict,
+	v1alpha1.CSVReasonCannotModifyStaticOperatorGroupProvidedAPIs,
+	v1alpha1.CSVReasonInvalidWebhookDescription,
+)
+
+// waitForCSVSucceeded waits for the CSV to reach the succeeded phase. It fails
+// as soon as the CSV fails for a reason that OLM does not retry, and otherwise
+// keeps waiting until ctx is done.
+func (i *OperatorInstall) waitForCSVSucceeded(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) error {
+	csvKey := client.ObjectKeyFromObject(csv)
+	if err := wait.PollUntilContextCancel(ctx, time.Millisecond*250, true, func(conditionCtx context.Context) (bool, error) {
+		if err := i.config.Client.Get(conditionCtx, csvKey, csv); err != nil {
+			return false, err
+		}
+		switch {
+		case csv.Status.Phase == v1alpha1.CSVPhaseSucceeded:
+			return true, nil
+		case csv.Status.Phase == v1alpha1.CSVPhaseFailed && terminalCSVFailures.Has(csv.Status.Reason):
+			return false, fmt.Errorf("csv %q failed: %s: %s", csv.Name, csv.Status.Reason, csv.Status.Message)
+		}
+		return false, nil
+	}); err != nil {
+		if ctx.Err() != nil && csv.Status.Phase != "" {
+			return fmt.Errorf("waiting for clusterserviceversion to succeed (last phase %q: %s): %w", csv.Status.Phase, csv.Status.Message, err)
+		}
+		return fmt.Errorf("waiting for clusterserviceversion to succeed: %w", err)
+	}
+	return nil
+}
+
+func (i *OperatorInstall) findSubscription(ctx context.Context) (*v1alpha1.Subscription, error) {
+	subs := v1alpha1.SubscriptionList{}
+	if err := i.config.Client.List(ctx, &subs, client.InNamespace(i.config.Namespace)); err != nil {
+		return nil, fmt.Errorf("list subscriptions: %w", err)
+	}
+	for _, s := range subs.Items {
+		s := s
+		if s.Spec != nil && s.Spec.Package == i.Package {
+			return &s, nil
+		}
+	}
+	return nil, nil
+}
+
+// adoptSubscription reports the CSV of an existing subscription for the
+// package if it matches the requested install. A subscription with
+// different settings is patched if Update is set, and refused otherwise.
+func (i *OperatorInstall) adoptSubscription(ctx context.Context, sub *v1alpha1.Subscription, pm *operator.PackageManifest, pc *operator.PackageChannel) (*v1alpha1.ClusterServiceVersion, error) {
+	want, err := i.buildSubscription(types.NamespacedName{Namespace: sub.Namespace, Name: sub.Name}, pm, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	if i.Version != "" && !operator.IsVersionConstraint(i.Version) && sub.Status.InstalledCSV != "" && sub.Status.InstalledCSV != want.Spec.StartingCSV {
+		return nil, fmt.Errorf("subscription %q already has csv %q installed, not %q; use upgrade or uninstall to change the installed version",
+			sub.Name, sub.Status.InstalledCSV, want.Spec.StartingCSV)
+	}
+
+	if diff := i.subscriptionDiff(sub, want, pm); len(diff) > 0 {
+		if !i.Update {
+			return nil, fmt.Errorf("subscription %q for package %q already exists with different settings; use --update to change them:\n  %s",
+				sub.Name, i.Package, strings.Join(diff, "\n  "))
+		}
+		base := sub.DeepCopy()
+		sub.Spec.Channel = want.Spec.Channel
+		sub.Spec.CatalogSource = want.Spec.CatalogSource
+		sub.Spec.CatalogSourceNamespace = want.Spec.CatalogSourceNamespace
+		if i.Approval != "" {
+			sub.Spec.InstallPlanApproval = i.Approval
+		}
+		if want.Spec.StartingCSV != "" {
+			sub.Spec.StartingCSV = want.Spec.StartingCSV
+		}
+		if constraint, ok := want.Annotations[subscription.VersionConstraintAnnotation]; ok {
+			subscription.VersionConstraint(constraint)(sub)
+		} else {
+			delete(sub.Annotations, subscription.VersionConstraintAnnotation)
+		}
+		if err := i.config.Client.Patch(ctx, sub, client.MergeFrom(base)); err != nil {
+			return nil, fmt.Errorf("update subscription: %w", err)
+		}
+		i.emit(Event{
+			Type:    EventObjectUpdated,
+			Phase:   InstallPhaseSubscription,
+			Object:  ReferenceTo(v1alpha1.SubscriptionKind, sub),
+			Message: fmt.Sprintf("subscription %q updated: %s", sub.Name, strings.Join(diff, ", ")),
+		})
+		i.emit(Event{Type: EventPhaseCompleted, Phase: InstallPhaseSubscription})
+	} else {
+		i.emit(Event{
+			Type:    EventPhaseCompleted,
+			Phase:   InstallPhaseSubscription,
+			Message: fmt.Sprintf("subscription %q already exists", sub.Name),
+		})
+	}
+
+	if sub.Status.InstalledCSV == "" {
+		return i.waitForInitialInstall(ctx, sub)
+	}
+	csv := &v1alpha1.ClusterServiceVersion{}
+	if err := i.config.Client.Get(ctx, types.NamespacedName{Namespace: sub.Namespace, Name: sub.Status.InstalledCSV}, csv); err != nil {
+		return nil, fmt.Errorf("get clusterserviceversion: %w", err)
+	}
+	return csv, nil
+}
+
+// subscriptionDiff describes how the existing subscription differs from the
+// requested one, one line per field. An empty channel selects the package's
+// default channel. The approval is only compared if one was requested, and
+// the starting CSV only for installs that ask for a version, since it has no
+// effect once the operator is installed.
+func (i *OperatorInstall) subscriptionDiff(have, want *v1alpha1.Subscription, pm *operator.PackageManifest) []string {
+	diff := []string{}
+	field := func(name, h, w string) {
+		if h != w {
+			diff = append(diff, fmt.Sprintf("%s: %q -> %q", name, h, w))
+		}
+	}
+	channel := func(c string) string {
+		if c == "" {
+			return pm.GetDefaultChannel()
+		}
+		return c
+	}
+	field("channel", channel(have.Spec.Channel), channel(want.Spec.Channel))
+	field("source", have.Spec.CatalogSourceNamespace+"/"+have.Spec.CatalogSource, want.Spec.CatalogSourceNamespace+"/"+want.Spec.CatalogSource)
+	if i.Approval != "" {
+		field("approval", string(have.Spec.InstallPlanApproval), string(i.Approval))
+	}
+	if i.Version != "" {
+		if have.Status.InstalledCSV == "" {
+			field("startingCSV", have.Spec.StartingCSV, want.Spec.StartingCSV)
+		}
+		field("version constraint", have.Annotations[subscription.VersionConstraintAnnotation], want.Annotations[subscription.VersionConstraintAnnotation])
+	}
+	return diff
+}
+
+// checkOperatorGroup returns the operator group of the namespace if the
+// operator can be installed with it. If the namespace has none and one may be
+// created, it returns the target namespaces to create it with instead.
+func (i *OperatorInstall) checkOperatorGroup(ctx context.Context, pm *operator.PackageManifest, pc *operator.PackageChannel) (*v1.OperatorGroup, []string, error) {
+	og, err := i.getOperatorGroup(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	operatorInstallModes := pc.GetSupportedInstallModes()
+	if operatorInstallModes.Len() == 0 {
+		return nil, nil, fmt.Errorf("operator %q is not installable: operator defined no supported install modes", pm.Name)
+	}
+
+	desired := operator.PossibleInstallModes(i.config.Namespace, i.WatchNamespaces)
+
+	supported := operatorInstallModes.Intersection(desired)
+	if supported.Len() == 0 {
+		return nil, nil, fmt.Errorf("operator %q is not installable: install modes supported by operator (%q) not compatible with install modes supported by desired watches (%q)",
+			pm.Name,
+			strings.Join(sets.List[string](operatorInstallModes), ","),
+			strings.Join(sets.List[string](desired), ","),
+		)
+	}
+
+	if og != nil {
+		if err := operator.ValidateOperatorGroup(*og, operatorInstallModes, desired); err != nil {
+			return nil, nil, fmt.Errorf("operator %q not installable: %w", pm.Name, err)
+		}
+		if i.ServiceAccount != "" && og.Spec.ServiceAccountName != i.ServiceAccount {
+			return nil, nil, fmt.Errorf("operator %q not installable: existing operator group %q uses service account %q, not %q",
+				pm.Name, og.Name, og.Spec.ServiceAccountName, i.ServiceAccount)
+		}
+		return og, nil, nil
+	}
+
+	if !i.CreateOperatorGroup {
+		return nil, nil, fmt.Errorf("namespace %q has no existing operator group; use --create-operator-group to create one automatically", i.config.Namespace)
+	}
+	return nil, i.getTargetNamespaces(supported), nil
+}
+
+func (i OperatorInstall) getOperatorGroup(ctx context.Context) (*v1.OperatorGroup, error) {
+	ogs := &v1.OperatorGroupList{}
+	err := i.config.Client.List(ctx, ogs, client.InNamespace(i.config.Namespace))
+	if err != nil {
+		return nil, fmt.Errorf("list operator groups: %w", err)
+	}
+
+	switch len(ogs.Items) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &ogs.Items[0], nil
+	default:
+		return nil, fmt.Errorf("namespace %q has more than one operator group; use 'operatorgroup resolve' to leave it with one", i.config.Namespace)
+	}
+}
+
+func (i *OperatorInstall) getTargetNamespaces(supported sets.Set[string]) []string {
+	switch {
+	case supported.Has(string(v1alpha1.InstallModeTypeAllNamespaces)):
+		return nil
+	case supported.Has(string(v1alpha1.InstallModeTypeOwnNamespace)):
+		return []string{i.config.Namespace}
+	default:
+		return i.WatchNamespaces
+	}
+}
+
+func (i *OperatorInstall) createOperatorGroup(ctx context.Context, targetNamespaces []string) error {
+	og := &v1.OperatorGroup{}
+	og.SetName(i.config.Namespace)
+	og.SetNamespace(i.config.Namespace)
+	og.Spec.TargetNamespaces = targetNamespaces
+	og.Spec.ServiceAccountName = i.ServiceAccount
+
+	if err := cluster.Apply(ctx, i.config.Client, i.config.Scheme, og); err != nil {
+		return fmt.Errorf("create operator group: %w", err)
+	}
+	i.emit(Event{
+		Type:    EventObjectCreated,
+		Phase:   InstallPhaseSubscription,
+		Object:  ReferenceTo(v1.OperatorGroupKind, og),
+		Message: fmt.Sprintf("operatorgroup %q applied", og.Name),
+	})
+	return nil
+}
+
+func (i *OperatorInstall) ensureServiceAccount(ctx context.Context) error {
+	sa := &corev1.ServiceAccount{}
+	sa.SetName(i.ServiceAccount)
+	sa.SetNamespace(i.config.Namespace)
+	if err := i.config.Client.Create(ctx, sa); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil
+		}
+		return fmt.Errorf("create service account: %w", err)
+	}
+	i.emit(Event{
+		Type:    EventObjectCreated,
+		Phase:   InstallPhaseSubscription,
+		Object:  ReferenceTo("ServiceAccount", sa),
+		Message: fmt.Sprintf("serviceaccount %q created", sa.Name),
+	})
+	return nil
+}
+
+func (i *OperatorInstall) buildSubscription(subKey types.NamespacedName, pm *operator.PackageManifest, pc *operator.PackageChannel) (*v1alpha1.Subscription, error) {
+	approval := i.Approval
+	if approval == "" {
+		approval = v1alpha1.ApprovalManual
+	}
+	opts := []subscription.Option{
+		subscription.InstallPlanApproval(approval),
+	}
+
+	if i.Version != "" {
+		startingCSV, err := pc.StartingCSV(i.Version)
+		if err != nil {
+			return nil, fmt.Errorf("get starting CSV: %w", err)
+		}
+		opts = append(opts, subscription.StartingCSV(startingCSV))
+		if operator.IsVersionConstraint(i.Version) {
+			i.emit(Event{
+				Type:    EventInfo,
+				Phase:   InstallPhaseSubscription,
+				Message: fmt.Sprintf("version constraint %q resolved to %q", i.Version, startingCSV),
+			})
+			opts = append(opts, subscription.VersionConstraint(i.Version))
+		}
+	}
+
+	sourceKey := types.NamespacedName{
+		Namespace: pm.Status.CatalogSourceNamespace,
+		Name:      pm.Status.CatalogSource,
+	}
+	return subscription.Build(subKey, i.Channel, sourceKey, opts...), nil
+}
+
+func (i *OperatorInstall) getInstallPlan(ctx context.Context, sub *v1alpha1.Subscription) (*v1alpha1.InstallPlan, error) {
+	subKey := client.ObjectKeyFromObject(sub)
+	if err := wait.PollUntilContextCancel(ctx, time.Millisecond*250, true, func(conditionCtx context.Context) (bool, error) {
+		if err := i.config.Client.Get(conditionCtx, subKey, sub); err != nil {
+			return false, err
+		}
+		if sub.Status.InstallPlanRef != nil {
+			return true, nil
+		}
+		return false, nil
+	}); err != nil {
+		return nil, fmt.Errorf("waiting for install plan to exist: %w", err)
+	}
+
+	ip := v1alpha1.InstallPlan{}
+	ipKey := types.NamespacedName{
+		Namespace: sub.Status.InstallPlanRef.Namespace,
+		Name:      sub.Status.InstallPlanRef.Name,
+	}
+	if err := i.config.Client.Get(ctx, ipKey, &ip); err != nil {
+		return nil, fmt.Errorf("get install plan: %w", err)
+	}
+	return &ip, nil
+}
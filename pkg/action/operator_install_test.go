@@ -0,0 +1,597 @@
+package action_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("OperatorInstall", func() {
+	var (
+		cfg   action.Configuration
+		sub   *v1alpha1.Subscription
+		pms   []operatorsv1.PackageManifest
+		extra []client.Object
+		funcs interceptor.Funcs
+	)
+
+	packageManifest := func(catalogNamespace, catalog string) operatorsv1.PackageManifest {
+		return operatorsv1.PackageManifest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "etcd",
+				Namespace: "etcd-namespace",
+				Labels:    map[string]string{"catalog": catalog, "catalog-namespace": catalogNamespace},
+			},
+			Status: operatorsv1.PackageManifestStatus{
+				CatalogSource:          catalog,
+				CatalogSourceNamespace: catalogNamespace,
+				DefaultChannel:         "stable",
+				Channels: []operatorsv1.PackageChannel{
+					{
+						Name:       "stable",
+						CurrentCSV: "etcdoperator.v1.4.0",
+						Entries: []operatorsv1.ChannelEntry{
+							{Name: "etcdoperator.v1.4.0", Version: "1.4.0"},
+							{Name: "etcdoperator.v1.3.0", Version: "1.3.0"},
+						},
+					},
+					{
+						Name:       "alpha",
+						CurrentCSV: "etcdoperator.v1.5.0-alpha",
+						Entries: []operatorsv1.ChannelEntry{
+							{Name: "etcdoperator.v1.5.0-alpha", Version: "1.5.0-alpha"},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		pms = []operatorsv1.PackageManifest{packageManifest("olm", "operatorhubio")}
+		extra = nil
+
+		// packageserver serves a package manifest per catalog under the
+		// package's name, which the fake client's tracker cannot store, so
+		// package manifest lists are served from pms.
+		funcs = applyFuncs
+		funcs.List = func(ctx context.Context, cl client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			pmList, ok := list.(*operatorsv1.PackageManifestList)
+			if !ok {
+				return cl.List(ctx, list, opts...)
+			}
+			lo := &client.ListOptions{}
+			lo.ApplyOptions(opts)
+			pmList.Items = nil
+			for _, pm := range pms {
+				if lo.LabelSelector == nil || lo.LabelSelector.Matches(labels.Set(pm.Labels)) {
+					pmList.Items = append(pmList.Items, pm)
+				}
+			}
+			return nil
+		}
+
+		sub = &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd-subscription", Namespace: "etcd-namespace"},
+			Spec: &v1alpha1.SubscriptionSpec{
+				Package:                "etcd",
+				Channel:                "stable",
+				CatalogSource:          "operatorhubio",
+				CatalogSourceNamespace: "olm",
+				InstallPlanApproval:    v1alpha1.ApprovalManual,
+			},
+			Status: v1alpha1.SubscriptionStatus{InstalledCSV: "etcdoperator.v1.4.0"},
+		}
+	})
+
+	build := func() {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+
+		csv := &v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcdoperator.v1.4.0", Namespace: "etcd-namespace"},
+			Status:     v1alpha1.ClusterServiceVersionStatus{Phase: v1alpha1.CSVPhaseSucceeded},
+		}
+		og := &v1.OperatorGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd-namespace", Namespace: "etcd-namespace"},
+		}
+
+		objs := append([]client.Object{csv, og}, extra...)
+		if sub != nil {
+			objs = append(objs, sub)
+		}
+		cfg.Scheme = sch
+		cfg.Client = fake.NewClientBuilder().
+			WithObjects(objs...).
+			WithStatusSubresource(&v1alpha1.Subscription{}).
+			WithScheme(sch).
+			WithInterceptorFuncs(funcs).
+			Build()
+		cfg.Namespace = "etcd-namespace"
+	}
+
+	newInstall := func() *action.OperatorInstall {
+		i := action.NewOperatorInstall(&cfg)
+		i.Package = "etcd"
+		i.Approval = v1alpha1.ApprovalManual
+		return i
+	}
+
+	getSubscription := func() *v1alpha1.Subscription {
+		s := &v1alpha1.Subscription{}
+		Expect(cfg.Client.Get(context.TODO(), types.NamespacedName{Namespace: "etcd-namespace", Name: "etcd-subscription"}, s)).To(Succeed())
+		return s
+	}
+
+	It("should report the csv of a matching subscription", func() {
+		build()
+		csv, err := newInstall().Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(csv.Name).To(Equal("etcdoperator.v1.4.0"))
+
+		subs := &v1alpha1.SubscriptionList{}
+		Expect(cfg.Client.List(context.TODO(), subs)).To(Succeed())
+		Expect(subs.Items).To(HaveLen(1))
+	})
+
+	It("should treat the default channel as matching an empty channel", func() {
+		sub.Spec.Channel = ""
+		build()
+		i := newInstall()
+		i.Channel = "stable"
+		_, err := i.Run(context.TODO())
+		Expect(err).To(BeNil())
+	})
+
+	It("should refuse a subscription with different settings", func() {
+		build()
+		i := newInstall()
+		i.Channel = "alpha"
+		i.Approval = v1alpha1.ApprovalAutomatic
+		_, err := i.Run(context.TODO())
+		Expect(err).To(MatchError(And(
+			ContainSubstring(`subscription "etcd-subscription" for package "etcd" already exists with different settings; use --update`),
+			ContainSubstring(`channel: "stable" -> "alpha"`),
+			ContainSubstring(`approval: "Manual" -> "Automatic"`),
+		)))
+		Expect(getSubscription().Spec.Channel).To(Equal("stable"))
+	})
+
+	It("should update a subscription with different settings", func() {
+		build()
+		i := newInstall()
+		i.Channel = "alpha"
+		i.Approval = v1alpha1.ApprovalAutomatic
+		i.Update = true
+		csv, err := i.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(csv.Name).To(Equal("etcdoperator.v1.4.0"))
+
+		s := getSubscription()
+		Expect(s.Spec.Channel).To(Equal("alpha"))
+		Expect(s.Spec.InstallPlanApproval).To(Equal(v1alpha1.ApprovalAutomatic))
+		Expect(s.Spec.CatalogSource).To(Equal("operatorhubio"))
+	})
+
+	It("should keep the approval of a subscription when none is requested", func() {
+		sub.Spec.InstallPlanApproval = v1alpha1.ApprovalAutomatic
+		build()
+		i := newInstall()
+		i.Approval = ""
+		_, err := i.Run(context.TODO())
+		Expect(err).To(BeNil())
+
+		i = newInstall()
+		i.Approval = ""
+		i.Channel = "alpha"
+		i.Update = true
+		_, err = i.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(getSubscription().Spec.InstallPlanApproval).To(Equal(v1alpha1.ApprovalAutomatic))
+	})
+
+	It("should create a subscription with manual approval when none is requested", func() {
+		sub = nil
+		pms[0].Status.Channels[0].CurrentCSVDesc.InstallModes = []v1alpha1.InstallMode{
+			{Type: v1alpha1.InstallModeTypeAllNamespaces, Supported: true},
+		}
+		build()
+		i := newInstall()
+		i.Approval = ""
+
+		// The install is stopped while waiting for the install plan that
+		// OLM would create.
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+		_, err := i.Run(ctx)
+		Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+
+		s := &v1alpha1.Subscription{}
+		Expect(cfg.Client.Get(context.TODO(), types.NamespacedName{Namespace: "etcd-namespace", Name: "etcd"}, s)).To(Succeed())
+		Expect(s.Spec.InstallPlanApproval).To(Equal(v1alpha1.ApprovalManual))
+	})
+
+	It("should point to operatorgroup resolve for a namespace with several operator groups", func() {
+		sub = nil
+		extra = append(extra, &v1.OperatorGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: "etcd-namespace"},
+		})
+		build()
+		_, err := newInstall().Run(context.TODO())
+		Expect(err).To(MatchError(ContainSubstring(`namespace "etcd-namespace" has more than one operator group; use 'operatorgroup resolve'`)))
+	})
+
+	It("should not create a service account for a refused install", func() {
+		sub = nil
+		pms[0].Status.Channels[0].CurrentCSVDesc.InstallModes = []v1alpha1.InstallMode{
+			{Type: v1alpha1.InstallModeTypeAllNamespaces, Supported: true},
+		}
+		build()
+		i := newInstall()
+		i.ServiceAccount = "etcd-installer"
+		i.CreateServiceAccount = true
+		_, err := i.Run(context.TODO())
+		Expect(err).To(MatchError(ContainSubstring(`existing operator group "etcd-namespace" uses service account "", not "etcd-installer"`)))
+
+		sas := &corev1.ServiceAccountList{}
+		Expect(cfg.Client.List(context.TODO(), sas)).To(Succeed())
+		Expect(sas.Items).To(BeEmpty())
+	})
+
+	It("should report the progress of a pending install as events", func() {
+		sub.Status = v1alpha1.SubscriptionStatus{
+			InstallPlanRef: &corev1.ObjectReference{Namespace: "etcd-namespace", Name: "install-etcd"},
+		}
+		extra = append(extra, &v1alpha1.InstallPlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "install-etcd", Namespace: "etcd-namespace"},
+			Status: v1alpha1.InstallPlanStatus{
+				Phase: v1alpha1.InstallPlanPhaseComplete,
+				Plan: []*v1alpha1.Step{
+					{Resource: v1alpha1.StepResource{Kind: "ClusterServiceVersion", Name: "etcdoperator.v1.4.0"}},
+				},
+			},
+		})
+		build()
+
+		var events []action.Event
+		logged := []string{}
+		i := newInstall()
+		i.Events = action.EventSinkFunc(func(e action.Event) { events = append(events, e) })
+		i.Logf = func(format string, args ...interface{}) { logged = append(logged, fmt.Sprintf(format, args...)) }
+		csv, err := i.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(csv.Name).To(Equal("etcdoperator.v1.4.0"))
+
+		type step struct {
+			Type      action.EventType
+			Phase     string
+			Object    string
+			Condition string
+		}
+		steps := []step{}
+		for _, e := range events {
+			s := step{Type: e.Type, Phase: e.Phase, Condition: e.Condition}
+			if e.Object != nil {
+				s.Object = e.Object.Kind + "/" + e.Object.Name
+			}
+			steps = append(steps, s)
+		}
+		Expect(steps).To(Equal([]step{
+			{Type: action.EventPhaseStarted, Phase: action.InstallPhaseResolve},
+			{Type: action.EventPhaseCompleted, Phase: action.InstallPhaseResolve},
+			{Type: action.EventPhaseStarted, Phase: action.InstallPhaseSubscription},
+			{Type: action.EventPhaseCompleted, Phase: action.InstallPhaseSubscription},
+			{Type: action.EventPhaseStarted, Phase: action.InstallPhaseInstallPlan},
+			{Type: action.EventWaiting, Phase: action.InstallPhaseInstallPlan, Object: "Subscription/etcd-subscription", Condition: action.ConditionInstallPlanCreated},
+			{Type: action.EventConditionMet, Phase: action.InstallPhaseInstallPlan, Object: "Subscription/etcd-subscription", Condition: action.ConditionInstallPlanCreated},
+			{Type: action.EventObjectUpdated, Phase: action.InstallPhaseInstallPlan, Object: "InstallPlan/install-etcd"},
+			{Type: action.EventWaiting, Phase: action.InstallPhaseInstallPlan, Object: "InstallPlan/install-etcd", Condition: action.ConditionInstallPlanComplete},
+			{Type: action.EventConditionMet, Phase: action.InstallPhaseInstallPlan, Object: "InstallPlan/install-etcd", Condition: action.ConditionInstallPlanComplete},
+			{Type: action.EventPhaseCompleted, Phase: action.InstallPhaseInstallPlan},
+			{Type: action.EventPhaseStarted, Phase: action.InstallPhaseCSV},
+			{Type: action.EventWaiting, Phase: action.InstallPhaseCSV, Object: "ClusterServiceVersion/etcdoperator.v1.4.0", Condition: action.ConditionCSVSucceeded},
+			{Type: action.EventConditionMet, Phase: action.InstallPhaseCSV, Object: "ClusterServiceVersion/etcdoperator.v1.4.0", Condition: action.ConditionCSVSucceeded},
+			{Type: action.EventPhaseCompleted, Phase: action.InstallPhaseCSV},
+		}))
+		Expect(logged).To(Equal([]string{`subscription "etcd-subscription" already exists`}))
+
+		ip := &v1alpha1.InstallPlan{}
+		Expect(cfg.Client.Get(context.TODO(), types.NamespacedName{Namespace: "etcd-namespace", Name: "install-etcd"}, ip)).To(Succeed())
+		Expect(ip.Spec.Approved).To(BeTrue())
+	})
+
+	Context("with a pending install", func() {
+		var phases []v1alpha1.ClusterServiceVersionStatus
+
+		BeforeEach(func() {
+			sub.Status = v1alpha1.SubscriptionStatus{
+				InstallPlanRef: &corev1.ObjectReference{Namespace: "etcd-namespace", Name: "install-etcd"},
+			}
+			extra = append(extra, &v1alpha1.InstallPlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "install-etcd", Namespace: "etcd-namespace"},
+				Status: v1alpha1.InstallPlanStatus{
+					Phase: v1alpha1.InstallPlanPhaseComplete,
+					Plan: []*v1alpha1.Step{
+						{Resource: v1alpha1.StepResource{Kind: "ClusterServiceVersion", Name: "etcdoperator.v1.4.0"}},
+					},
+				},
+			})
+
+			// Each get of the csv reports the next of phases, then the
+			// stored csv, which has succeeded.
+			phases = nil
+			funcs.Get = func(ctx context.Context, cl client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if err := cl.Get(ctx, key, obj, opts...); err != nil {
+					return err
+				}
+				if csv, ok := obj.(*v1alpha1.ClusterServiceVersion); ok && len(phases) > 0 {
+					csv.Status, phases = phases[0], phases[1:]
+				}
+				return nil
+			}
+		})
+
+		It("should wait for a csv that fails before it succeeds", func() {
+			phases = []v1alpha1.ClusterServiceVersionStatus{
+				{Phase: v1alpha1.CSVPhaseInstalling},
+				{Phase: v1alpha1.CSVPhaseFailed, Reason: v1alpha1.CSVReasonComponentUnhealthy, Message: "deployment not ready"},
+				{Phase: v1alpha1.CSVPhasePending, Reason: v1alpha1.CSVReasonNeedsReinstall},
+			}
+			build()
+			csv, err := newInstall().Run(context.TODO())
+			Expect(err).To(BeNil())
+			Expect(csv.Status.Phase).To(Equal(v1alpha1.CSVPhaseSucceeded))
+			Expect(phases).To(BeEmpty())
+		})
+
+		It("should fail as soon as the csv fails for a reason OLM does not retry", func() {
+			phases = []v1alpha1.ClusterServiceVersionStatus{
+				{Phase: v1alpha1.CSVPhaseInstalling},
+				{Phase: v1alpha1.CSVPhaseFailed, Reason: v1alpha1.CSVReasonUnsupportedOperatorGroup, Message: "OwnNamespace InstallModeType not supported"},
+			}
+			build()
+			_, err := newInstall().Run(context.TODO())
+			Expect(err).To(MatchError(ContainSubstring(`csv "etcdoperator.v1.4.0" failed: UnsupportedOperatorGroup: OwnNamespace InstallModeType not supported`)))
+		})
+
+		It("should report the last phase of the csv when the context is done", func() {
+			phases = []v1alpha1.ClusterServiceVersionStatus{
+				{Phase: v1alpha1.CSVPhaseInstalling},
+				{Phase: v1alpha1.CSVPhaseFailed, Reason: v1alpha1.CSVReasonInstallCheckFailed, Message: "install timeout"},
+				{Phase: v1alpha1.CSVPhaseFailed, Reason: v1alpha1.CSVReasonInstallCheckFailed, Message: "install timeout"},
+				{Phase: v1alpha1.CSVPhaseFailed, Reason: v1alpha1.CSVReasonInstallCheckFailed, Message: "install timeout"},
+			}
+			build()
+			ctx, cancel := context.WithTimeout(context.TODO(), 400*time.Millisecond)
+			defer cancel()
+			_, err := newInstall().Run(ctx)
+			Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring(`last phase "Failed": install timeout`)))
+		})
+	})
+
+	It("should report the error that stopped the install as an event", func() {
+		build()
+		var events []action.Event
+		i := newInstall()
+		i.Channel = "alpha"
+		i.Events = action.EventSinkFunc(func(e action.Event) { events = append(events, e) })
+		_, err := i.Run(context.TODO())
+		Expect(err).NotTo(BeNil())
+		Expect(events).NotTo(BeEmpty())
+		last := events[len(events)-1]
+		Expect(last.Type).To(Equal(action.EventError))
+		Expect(last.Err).To(Equal(err))
+	})
+
+	It("should refuse to change the installed version", func() {
+		build()
+		i := newInstall()
+		i.Version = "1.3.0"
+		i.Update = true
+		_, err := i.Run(context.TODO())
+		Expect(err).To(MatchError(ContainSubstring(`already has csv "etcdoperator.v1.4.0" installed, not "etcdoperator.v1.3.0"`)))
+	})
+
+	It("should return a typed error for a missing package", func() {
+		pms = nil
+		build()
+		_, err := newInstall().Run(context.TODO())
+		var notFound *action.ErrPackageNotFound
+		Expect(errors.As(err, &notFound)).To(BeTrue())
+		Expect(notFound.PackageName).To(Equal("etcd"))
+	})
+
+	It("should return a typed error for a missing channel", func() {
+		build()
+		i := newInstall()
+		i.Channel = "beta"
+		_, err := i.Run(context.TODO())
+		var notFound action.ErrChannelNotFound
+		Expect(errors.As(err, &notFound)).To(BeTrue())
+		Expect(notFound.ChannelName).To(Equal("beta"))
+	})
+
+	It("should return a typed error for a package without a default channel", func() {
+		pm := packageManifest("olm", "operatorhubio")
+		pm.Status.DefaultChannel = ""
+		pms = []operatorsv1.PackageManifest{pm}
+		build()
+		_, err := newInstall().Run(context.TODO())
+		Expect(errors.As(err, &action.ErrNoDefaultChannel{})).To(BeTrue())
+	})
+
+	It("should stop waiting when the context is canceled", func() {
+		sub.Status = v1alpha1.SubscriptionStatus{}
+		build()
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+		_, err := newInstall().Run(ctx)
+		Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+	})
+
+	Context("with a version constraint", func() {
+		BeforeEach(func() {
+			sub = nil
+			pms[0].Status.Channels[0].CurrentCSVDesc.InstallModes = []v1alpha1.InstallMode{
+				{Type: v1alpha1.InstallModeTypeAllNamespaces, Supported: true},
+			}
+			pms[0].Status.Channels[0].Entries = []operatorsv1.ChannelEntry{
+				{Name: "etcdoperator.v1.3.0", Version: "1.3.0"},
+				{Name: "etcdoperator.v1.4.1", Version: "1.4.1"},
+				{Name: "etcdoperator.v1.4.0", Version: "1.4.0"},
+				{Name: "etcdoperator.v2.0.0", Version: "2.0.0"},
+			}
+		})
+
+		// install runs an install that stops while waiting for the install
+		// plan OLM would create, and returns the subscription it created.
+		install := func(i *action.OperatorInstall) *v1alpha1.Subscription {
+			ctx, cancel := context.WithCancel(context.TODO())
+			cancel()
+			_, err := i.Run(ctx)
+			Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+
+			s := &v1alpha1.Subscription{}
+			Expect(cfg.Client.Get(context.TODO(), types.NamespacedName{Namespace: "etcd-namespace", Name: "etcd"}, s)).To(Succeed())
+			return s
+		}
+
+		It("should start from the highest entry matching the constraint", func() {
+			build()
+			i := newInstall()
+			i.Version = "~1.4"
+			s := install(i)
+			Expect(s.Spec.StartingCSV).To(Equal("etcdoperator.v1.4.1"))
+			Expect(s.Annotations).To(HaveKeyWithValue("operators.operatorframework.io/kubectl-operator-version-constraint", "~1.4"))
+		})
+
+		It("should force manual approval", func() {
+			build()
+			var warnings []string
+			i := newInstall()
+			i.Version = ">=1.3 <2.0"
+			i.Approval = v1alpha1.ApprovalAutomatic
+			i.Events = action.EventSinkFunc(func(e action.Event) {
+				if e.Type == action.EventWarning {
+					warnings = append(warnings, e.Message)
+				}
+			})
+			s := install(i)
+			Expect(s.Spec.InstallPlanApproval).To(Equal(v1alpha1.ApprovalManual))
+			Expect(s.Spec.StartingCSV).To(Equal("etcdoperator.v1.4.1"))
+			Expect(warnings).To(Equal([]string{`version constraint ">=1.3 <2.0" requires Manual approval; overriding approval "Automatic"`}))
+		})
+
+		It("should not record a constraint for an exact version", func() {
+			build()
+			i := newInstall()
+			i.Version = "1.4.0"
+			s := install(i)
+			Expect(s.Spec.StartingCSV).To(Equal("etcdoperator.v1.4.0"))
+			Expect(s.Annotations).NotTo(HaveKey("operators.operatorframework.io/kubectl-operator-version-constraint"))
+		})
+
+		It("should fail if no entry matches the constraint", func() {
+			build()
+			Expect(cfg.Client.DeleteAllOf(context.TODO(), &v1.OperatorGroup{}, client.InNamespace("etcd-namespace"))).To(Succeed())
+			i := newInstall()
+			i.Version = ">=3.0"
+			i.CreateOperatorGroup = true
+			i.ServiceAccount = "etcd-installer"
+			i.CreateServiceAccount = true
+			_, err := i.Run(context.TODO())
+			Expect(err).To(MatchError(ContainSubstring(`no version in channel "stable" satisfies constraint ">=3.0"`)))
+
+			subs := &v1alpha1.SubscriptionList{}
+			Expect(cfg.Client.List(context.TODO(), subs)).To(Succeed())
+			Expect(subs.Items).To(BeEmpty())
+			ogs := &v1.OperatorGroupList{}
+			Expect(cfg.Client.List(context.TODO(), ogs)).To(Succeed())
+			Expect(ogs.Items).To(BeEmpty())
+			sas := &corev1.ServiceAccountList{}
+			Expect(cfg.Client.List(context.TODO(), sas)).To(Succeed())
+			Expect(sas.Items).To(BeEmpty())
+		})
+
+		It("should fail for a channel that does not list its entries", func() {
+			pms[0].Status.Channels[0].Entries = nil
+			build()
+			i := newInstall()
+			i.Version = "~1.4"
+			_, err := i.Run(context.TODO())
+			Expect(err).To(MatchError(ContainSubstring(`cannot resolve version constraint "~1.4": channel "stable" does not list its entries`)))
+		})
+	})
+
+	Context("with a package served by several catalogs", func() {
+		BeforeEach(func() {
+			pms = append(pms, packageManifest("mirrors", "operatorhubio"), packageManifest("mirrors", "internal"))
+		})
+
+		It("should keep the catalog of an existing subscription", func() {
+			build()
+			csv, err := newInstall().Run(context.TODO())
+			Expect(err).To(BeNil())
+			Expect(csv.Name).To(Equal("etcdoperator.v1.4.0"))
+		})
+
+		It("should require a catalog for a new subscription", func() {
+			sub = nil
+			build()
+			_, err := newInstall().Run(context.TODO())
+			Expect(err).To(MatchError(ContainSubstring(`package "etcd" is served by more than one catalog (mirrors/internal, mirrors/operatorhubio, olm/operatorhubio); use --catalog`)))
+		})
+
+		It("should refuse a catalog name found in several namespaces", func() {
+			build()
+			i := newInstall()
+			i.Catalog.Name = "operatorhubio"
+			_, err := i.Run(context.TODO())
+			Expect(err).To(MatchError(ContainSubstring(`(mirrors/operatorhubio, olm/operatorhubio)`)))
+		})
+
+		It("should install from the chosen catalog", func() {
+			build()
+			i := newInstall()
+			Expect(i.Catalog.Set("olm/operatorhubio")).To(Succeed())
+			csv, err := i.Run(context.TODO())
+			Expect(err).To(BeNil())
+			Expect(csv.Name).To(Equal("etcdoperator.v1.4.0"))
+		})
+
+		It("should build the subscription source from the chosen catalog", func() {
+			build()
+			i := newInstall()
+			Expect(i.Catalog.Set("internal")).To(Succeed())
+			_, err := i.Run(context.TODO())
+			Expect(err).To(MatchError(ContainSubstring(`source: "olm/operatorhubio" -> "mirrors/internal"`)))
+		})
+
+		It("should report a package missing from the chosen catalog", func() {
+			build()
+			i := newInstall()
+			Expect(i.Catalog.Set("olm/community")).To(Succeed())
+			_, err := i.Run(context.TODO())
+			Expect(err).To(MatchError(ContainSubstring(`package "etcd" not found in catalog "olm/community"`)))
+		})
+	})
+})
@@ -0,0 +1,34 @@
+package action
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+// OperatorList lists the subscriptions in the configured namespace.
+type OperatorList struct {
+	config *Configuration
+}
+
+func NewOperatorList(cfg *Configuration) *OperatorList {
+	return &OperatorList{cfg}
+}
+
+func (l *OperatorList) Run(ctx context.Context) ([]v1alpha1.Subscription, error) {
+	subs := v1alpha1.SubscriptionList{}
+	if err := l.config.Client.List(ctx, &subs, client.InNamespace(l.config.Namespace)); err != nil {
+		return nil, err
+	}
+	return subs.Items, nil
+}
+
+// Watch reports the subscriptions Run lists, and then every change to them
+// until ctx is done or handler returns an error. Only subscriptions are
+// watched; changes to their CSVs and catalog sources are not reported unless
+// they change the subscriptions' status.
+func (l *OperatorList) Watch(ctx context.Context, handler func(WatchEvent) error) error {
+	return l.config.Watch(ctx, &v1alpha1.SubscriptionList{}, handler, client.InNamespace(l.config.Namespace))
+}
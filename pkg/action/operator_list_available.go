@@ -0,0 +1,246 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/operator"
+)
+
+// PackageManifest is a package served by a catalog, and PackageChannel one of
+// its channels.
+type (
+	PackageManifest = operator.PackageManifest
+	PackageChannel  = operator.PackageChannel
+)
+
+// ListAvailableOptions are the settings of an OperatorListAvailable.
+type ListAvailableOptions struct {
+	Catalog NamespacedName
+	Package string
+
+	// Search is a free-text query matched against the package name, display
+	// name, keywords and description. Every whitespace-separated term must
+	// match for a package to be listed, and results are sorted by relevance.
+	Search string
+
+	// Provider, CapabilityLevel and InstallMode filter on the head of the
+	// package's default channel, or of Channel when it is set. Channel
+	// filters out packages that do not have a channel with that name.
+	Provider        string
+	CapabilityLevel string
+	InstallMode     string
+	Channel         string
+}
+
+// OperatorListAvailable lists the packages served by the catalogs.
+type OperatorListAvailable struct {
+	config *Configuration
+
+	ListAvailableOptions
+}
+
+func NewOperatorListAvailable(cfg *Configuration) *OperatorListAvailable {
+	return &OperatorListAvailable{
+		config: cfg,
+	}
+}
+
+func (l *OperatorListAvailable) Run(ctx context.Context) ([]operator.PackageManifest, error) {
+	labelSelector := client.MatchingLabels{}
+	if l.Catalog.Name != "" {
+		labelSelector["catalog"] = l.Catalog.Name
+	}
+	if l.Catalog.Namespace != "" {
+		labelSelector["catalog-namespace"] = l.Catalog.Namespace
+	}
+
+	if l.Package != "" {
+		pm := v1.PackageManifest{}
+		if err := l.config.Client.Get(ctx, types.NamespacedName{Name: l.Package, Namespace: l.config.Namespace}, &pm); err != nil {
+			return nil, err
+		}
+		return l.filter([]v1.PackageManifest{pm}), nil
+	}
+
+	pms := v1.PackageManifestList{}
+	if err := l.config.Client.List(ctx, &pms, labelSelector, client.InNamespace(l.config.Namespace)); err != nil {
+		return nil, err
+	}
+	return l.filter(pms.Items), nil
+}
+
+// filter drops the packages that do not match the search and filter fields
+// and sorts the rest, by relevance if a search was given and by name otherwise.
+func (l *OperatorListAvailable) filter(pms []v1.PackageManifest) []operator.PackageManifest {
+	type scored struct {
+		pkg   operator.PackageManifest
+		score int
+	}
+	terms := strings.Fields(strings.ToLower(l.Search))
+
+	matches := make([]scored, 0, len(pms))
+	for _, pm := range pms {
+		pkg := operator.PackageManifest{PackageManifest: pm}
+		desc, ok := l.filterChannelDesc(pkg)
+		if !ok || !l.matchesFilters(pkg, desc) {
+			continue
+		}
+		score, ok := searchScore(pkg, desc, terms)
+		if !ok {
+			continue
+		}
+		matches = append(matches, scored{pkg, score})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].pkg.Name < matches[j].pkg.Name
+	})
+
+	pkgs := make([]operator.PackageManifest, 0, len(matches))
+	for _, m := range matches {
+		pkgs = append(pkgs, m.pkg)
+	}
+	return pkgs
+}
+
+// filterChannelDesc returns the channel head description used for filtering.
+// It returns false if the package does not have the requested channel.
+func (l *OperatorListAvailable) filterChannelDesc(pkg operator.PackageManifest) (v1.CSVDescription, bool) {
+	pc, err := pkg.GetChannel(l.Channel)
+	if err == nil {
+		return pc.CurrentCSVDesc, true
+	}
+	if l.Channel != "" {
+		return v1.CSVDescription{}, false
+	}
+	// Packages without a default channel can still be matched on
+	// their first channel.
+	if len(pkg.Status.Channels) > 0 {
+		return pkg.Status.Channels[0].CurrentCSVDesc, true
+	}
+	return v1.CSVDescription{}, true
+}
+
+func (l *OperatorListAvailable) matchesFilters(pkg operator.PackageManifest, desc v1.CSVDescription) bool {
+	if l.Provider != "" {
+		provider := strings.ToLower(l.Provider)
+		if !strings.Contains(strings.ToLower(pkg.Status.Provider.Name), provider) &&
+			!strings.Contains(strings.ToLower(desc.Provider.Name), provider) {
+			return false
+		}
+	}
+	if l.CapabilityLevel != "" && !strings.EqualFold(desc.Annotations[capabilitiesAnnotation], l.CapabilityLevel) {
+		return false
+	}
+	if l.InstallMode != "" && !supportsInstallMode(desc, l.InstallMode) {
+		return false
+	}
+	return true
+}
+
+func supportsInstallMode(desc v1.CSVDescription, installMode string) bool {
+	for _, im := range desc.InstallModes {
+		if im.Supported && strings.EqualFold(string(im.Type), installMode) {
+			return true
+		}
+	}
+	return false
+}
+
+// Relevance weights for each field a search term can match.
+const (
+	scoreNameExact       = 100
+	scoreNamePrefix      = 50
+	scoreNameContains    = 30
+	scoreDisplayName     = 20
+	scoreKeywordExact    = 15
+	scoreKeyword         = 10
+	scoreDescription     = 5
+	scoreLongDescription = 2
+)
+
+// searchScore returns the relevance of a package for the given lower-cased
+// search terms. It returns false if any term does not match the package.
+func searchScore(pkg operator.PackageManifest, desc v1.CSVDescription, terms []string) (int, bool) {
+	name := strings.ToLower(pkg.Name)
+	displayName := strings.ToLower(desc.DisplayName)
+	description := strings.ToLower(desc.Annotations[descriptionAnnotation])
+	longDescription := strings.ToLower(desc.LongDescription)
+
+	total := 0
+	for _, term := range terms {
+		score := 0
+		switch {
+		case name == term:
+			score += scoreNameExact
+		case strings.HasPrefix(name, term):
+			score += scoreNamePrefix
+		case strings.Contains(name, term):
+			score += scoreNameContains
+		}
+		if strings.Contains(displayName, term) {
+			score += scoreDisplayName
+		}
+		for _, kw := range desc.Keywords {
+			kw = strings.ToLower(kw)
+			if kw == term {
+				score += scoreKeywordExact
+				break
+			} else if strings.Contains(kw, term) {
+				score += scoreKeyword
+				break
+			}
+		}
+		if strings.Contains(description, term) {
+			score += scoreDescription
+		}
+		if strings.Contains(longDescription, term) {
+			score += scoreLongDescription
+		}
+		if score == 0 {
+			return 0, false
+		}
+		total += score
+	}
+	return total, true
+}
+
+// NamespacedName is a namespace and name that can be set from a
+// "[namespace/]name" flag value.
+type NamespacedName struct {
+	types.NamespacedName
+}
+
+func (f *NamespacedName) Set(str string) error {
+	split := strings.Split(str, "/")
+	switch len(split) {
+	case 0:
+	case 1:
+		f.Name = split[0]
+	case 2:
+		f.Namespace = split[0]
+		f.Name = split[1]
+	default:
+		return fmt.Errorf("invalid namespaced name value %q", str)
+	}
+	return nil
+}
+
+func (f NamespacedName) String() string {
+	return f.NamespacedName.String()
+}
+
+func (f NamespacedName) Type() string {
+	return "NamespacedNameValue"
+}
@@ -0,0 +1,134 @@
+package action_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("OperatorListAvailable", func() {
+	var cfg action.Configuration
+
+	newPackage := func(name, displayName, provider, capability string, keywords []string, allNamespaces bool, channels ...string) *operatorsv1.PackageManifest {
+		pm := &operatorsv1.PackageManifest{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "olm"},
+			Status: operatorsv1.PackageManifestStatus{
+				Provider:       operatorsv1.AppLink{Name: provider},
+				DefaultChannel: channels[0],
+			},
+		}
+		for _, ch := range channels {
+			pm.Status.Channels = append(pm.Status.Channels, operatorsv1.PackageChannel{
+				Name: ch,
+				CurrentCSVDesc: operatorsv1.CSVDescription{
+					DisplayName: displayName,
+					Provider:    operatorsv1.AppLink{Name: provider},
+					Annotations: map[string]string{
+						"capabilities": capability,
+						"description":  displayName + " operator",
+					},
+					Keywords: keywords,
+					InstallModes: []v1alpha1.InstallMode{
+						{Type: v1alpha1.InstallModeTypeOwnNamespace, Supported: true},
+						{Type: v1alpha1.InstallModeTypeAllNamespaces, Supported: allNamespaces},
+					},
+				},
+			})
+		}
+		return pm
+	}
+
+	names := func(pkgs []action.PackageManifest) []string {
+		out := make([]string, 0, len(pkgs))
+		for _, p := range pkgs {
+			out = append(out, p.Name)
+		}
+		return out
+	}
+
+	BeforeEach(func() {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+
+		cl := fake.NewClientBuilder().
+			WithObjects(
+				newPackage("etcd", "etcd", "CNCF", "Full Lifecycle", []string{"etcd", "key value", "database"}, false, "singlenamespace-alpha", "clusterwide-alpha"),
+				newPackage("postgresql", "PostgreSQL", "Crunchy Data", "Deep Insights", []string{"database", "sql"}, true, "stable"),
+				newPackage("redis-operator", "Redis", "Spotahome", "Basic Install", []string{"redis", "database", "cache"}, true, "stable"),
+				newPackage("prometheus", "Prometheus", "Red Hat", "Deep Insights", []string{"monitoring"}, true, "beta"),
+			).
+			WithScheme(sch).
+			Build()
+		cfg.Scheme = sch
+		cfg.Client = cl
+		cfg.Namespace = "olm"
+	})
+
+	It("should list all packages sorted by name", func() {
+		l := action.NewOperatorListAvailable(&cfg)
+		pkgs, err := l.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(names(pkgs)).To(Equal([]string{"etcd", "postgresql", "prometheus", "redis-operator"}))
+	})
+
+	It("should sort search results by relevance", func() {
+		l := action.NewOperatorListAvailable(&cfg)
+		l.Search = "redis"
+		pkgs, err := l.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(names(pkgs)).To(Equal([]string{"redis-operator"}))
+
+		l.Search = "database"
+		pkgs, err = l.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(names(pkgs)).To(Equal([]string{"etcd", "postgresql", "redis-operator"}))
+
+		l.Search = "sql"
+		pkgs, err = l.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(names(pkgs)).To(Equal([]string{"postgresql"}))
+	})
+
+	It("should require every search term to match", func() {
+		l := action.NewOperatorListAvailable(&cfg)
+		l.Search = "database cache"
+		pkgs, err := l.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(names(pkgs)).To(Equal([]string{"redis-operator"}))
+	})
+
+	It("should filter by provider, capability level, install mode and channel", func() {
+		l := action.NewOperatorListAvailable(&cfg)
+		l.Provider = "red hat"
+		pkgs, err := l.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(names(pkgs)).To(Equal([]string{"prometheus"}))
+
+		l = action.NewOperatorListAvailable(&cfg)
+		l.CapabilityLevel = "deep insights"
+		pkgs, err = l.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(names(pkgs)).To(Equal([]string{"postgresql", "prometheus"}))
+
+		l = action.NewOperatorListAvailable(&cfg)
+		l.InstallMode = "AllNamespaces"
+		pkgs, err = l.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(names(pkgs)).To(Equal([]string{"postgresql", "prometheus", "redis-operator"}))
+
+		l = action.NewOperatorListAvailable(&cfg)
+		l.Channel = "clusterwide-alpha"
+		pkgs, err = l.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(names(pkgs)).To(Equal([]string{"etcd"}))
+	})
+})
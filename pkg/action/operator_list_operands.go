@@ -97,33 +97,20 @@ func (o *OperatorListOperands) unzip(ctx context.Context, operator *v1.Operator)
 func (o *OperatorListOperands) list(ctx context.Context, crdDesc v1alpha1.CRDDescription, namespaces []string) (*unstructured.UnstructuredList, error) {
 	result := &unstructured.UnstructuredList{}
 
-	// get crd group from crd name
-	crd := apiextensionsv1.CustomResourceDefinition{}
-	crdKey := types.NamespacedName{
-		Name: crdDesc.Name,
-	}
-	err := o.config.Client.Get(ctx, crdKey, &crd)
+	list, err := o.emptyList(ctx, crdDesc)
 	if err != nil {
-		return nil, fmt.Errorf("get crd %q: %v", crdKey.String(), err)
-	}
-
-	list := unstructured.UnstructuredList{}
-	gvk := schema.GroupVersionKind{
-		Group:   crd.Spec.Group,
-		Version: crdDesc.Version,
-		Kind:    crd.Spec.Names.ListKind,
+		return nil, err
 	}
-	list.SetGroupVersionKind(gvk)
-	if err := o.config.Client.List(ctx, &list); err != nil {
+	if err := o.config.Client.List(ctx, list); err != nil {
 		return nil, err
 	}
 
 	// trim down CRs in list to match target namespaces
 	if len(namespaces) == 0 {
-		return &list, nil
+		return list, nil
 	}
 	for _, cr := range list.Items {
-		if cr.GetNamespace() == "" || inNamespace(cr.GetNamespace(), namespaces) {
+		if isOperand(&cr, namespaces) {
 			result.Items = append(result.Items, cr)
 		}
 	}
@@ -131,6 +118,41 @@ func (o *OperatorListOperands) list(ctx context.Context, crdDesc v1alpha1.CRDDes
 	return result, nil
 }
 
+// emptyList returns an empty list of the custom resources described by
+// crdDesc, with the group looked up from its CRD.
+func (o *OperatorListOperands) emptyList(ctx context.Context, crdDesc v1alpha1.CRDDescription) (*unstructured.UnstructuredList, error) {
+	crd := apiextensionsv1.CustomResourceDefinition{}
+	crdKey := types.NamespacedName{
+		Name: crdDesc.Name,
+	}
+	err := o.config.Client.Get(ctx, crdKey, &crd)
+	if err != nil {
+		return nil, fmt.Errorf("get crd %q: %w", crdKey.String(), err)
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   crd.Spec.Group,
+		Version: crdDesc.Version,
+		Kind:    crd.Spec.Names.ListKind,
+	})
+	return list, nil
+}
+
+// targetNamespaces returns the namespaces targeted by the operator group in
+// the configured namespace.
+func (o *OperatorListOperands) targetNamespaces(ctx context.Context) ([]string, error) {
+	ogList := v1.OperatorGroupList{}
+	options := client.ListOptions{Namespace: o.config.Namespace}
+	if err := o.config.Client.List(ctx, &ogList, &options); err != nil {
+		return nil, err
+	}
+	if len(ogList.Items) != 1 {
+		return nil, fmt.Errorf("unexpected number (%d) of operator groups found in namespace %s; a namespace must have exactly one operator group; use 'operatorgroup resolve' to fix it", len(ogList.Items), o.config.Namespace)
+	}
+	return ogList.Items[0].Status.Namespaces, nil
+}
+
 // ListAll wraps the above functions to provide a convenient command to go from package/namespace to custom resources.
 func (o *OperatorListOperands) listAll(ctx context.Context, packageName string) (*unstructured.UnstructuredList, error) {
 	operator, err := o.findOperator(ctx, packageName)
@@ -145,15 +167,10 @@ func (o *OperatorListOperands) listAll(ctx context.Context, packageName string)
 
 	// find all namespaces associated with operator via operatorgroup
 	// query for CRs in these namespaces
-	ogList := v1.OperatorGroupList{}
-	options := client.ListOptions{Namespace: o.config.Namespace}
-	if err := o.config.Client.List(ctx, &ogList, &options); err != nil {
+	namespaces, err := o.targetNamespaces(ctx)
+	if err != nil {
 		return nil, err
 	}
-	if len(ogList.Items) != 1 {
-		return nil, fmt.Errorf("unexpected number (%d) of operator groups found in namespace %s", len(ogList.Items), o.config.Namespace)
-	}
-	namespaces := ogList.Items[0].Status.Namespaces
 
 	var result unstructured.UnstructuredList
 	result.SetGroupVersionKind(schema.GroupVersionKind{
@@ -185,6 +202,12 @@ func (o *OperatorListOperands) listAll(ctx context.Context, packageName string)
 	return &result, nil
 }
 
+// isOperand returns true if obj is cluster-scoped or in one of the operator's
+// target namespaces. No namespaces means all namespaces are targeted.
+func isOperand(obj client.Object, namespaces []string) bool {
+	return len(namespaces) == 0 || obj.GetNamespace() == "" || inNamespace(obj.GetNamespace(), namespaces)
+}
+
 func inNamespace(ns string, namespaces []string) bool {
 	for _, n := range namespaces {
 		if n == ns || n == "" {
@@ -193,3 +216,126 @@ func inNamespace(ns string, namespaces []string) bool {
 	}
 	return false
 }
+
+// Watch reports the operator's operands as Run lists them, and then every
+// change to them until ctx is done or handler returns an error. The
+// operator's CSVs and the cluster's CRDs are watched as well, so operand kinds
+// that appear later, for example when an upgrade adds an owned CRD, are
+// followed too.
+func (o *OperatorListOperands) Watch(ctx context.Context, packageName string, handler func(WatchEvent) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		events   = make(chan WatchEvent)
+		stopped  = make(chan string)
+		changed  = make(chan struct{}, 1)
+		errs     = make(chan error, 1)
+		watching = map[string]bool{}
+	)
+	fail := func(err error) {
+		if ctx.Err() != nil {
+			return
+		}
+		select {
+		case errs <- err:
+		default:
+		}
+	}
+	forward := func(filter func(client.Object) bool) func(WatchEvent) error {
+		return func(e WatchEvent) error {
+			if !filter(e.Object) {
+				return nil
+			}
+			select {
+			case events <- e:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	signalChanged := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+	notify := func(WatchEvent) error {
+		signalChanged()
+		return nil
+	}
+
+	// sync starts a watch for each owned kind that is not watched yet. Kinds
+	// whose CRD does not exist yet are picked up once it is created.
+	sync := func() error {
+		operator, err := o.findOperator(ctx, packageName)
+		if err != nil {
+			return err
+		}
+		crdDescs, err := o.unzip(ctx, operator)
+		if err != nil {
+			return err
+		}
+		namespaces, err := o.targetNamespaces(ctx)
+		if err != nil {
+			return err
+		}
+		for _, crdDesc := range crdDescs {
+			key := crdDesc.Name + "/" + crdDesc.Version
+			if watching[key] {
+				continue
+			}
+			list, err := o.emptyList(ctx, crdDesc)
+			if apierrors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return err
+			}
+			watching[key] = true
+			go func() {
+				err := o.config.Watch(ctx, list, forward(func(obj client.Object) bool { return isOperand(obj, namespaces) }))
+				if apierrors.IsNotFound(err) {
+					// The CRD was deleted; watch the kind again if it returns.
+					select {
+					case stopped <- key:
+					case <-ctx.Done():
+					}
+					return
+				}
+				fail(err)
+			}()
+		}
+		return nil
+	}
+	if err := sync(); err != nil {
+		return err
+	}
+
+	go func() {
+		fail(o.config.Watch(ctx, &v1alpha1.ClusterServiceVersionList{}, notify, client.InNamespace(o.config.Namespace)))
+	}()
+	go func() {
+		fail(o.config.Watch(ctx, &apiextensionsv1.CustomResourceDefinitionList{}, notify))
+	}()
+
+	for {
+		select {
+		case e := <-events:
+			if err := handler(e); err != nil {
+				return err
+			}
+		case key := <-stopped:
+			delete(watching, key)
+			signalChanged()
+		case <-changed:
+			// The CSV may be mid-upgrade and not succeeded yet; keep
+			// watching the known kinds and sync again on its next change.
+			_ = sync()
+		case err := <-errs:
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
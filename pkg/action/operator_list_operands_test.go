@@ -12,6 +12,8 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	v1 "github.com/operator-framework/api/pkg/operators/v1"
@@ -186,6 +188,7 @@ var _ = Describe("OperatorListOperands", func() {
 		lister := action.NewOperatorListOperands(&cfg)
 		_, err := lister.Run(context.TODO(), "etcd")
 		Expect(err.Error()).To(ContainSubstring("unexpected number (0) of operator groups found in namespace etcd"))
+		Expect(err.Error()).To(ContainSubstring("use 'operatorgroup resolve' to fix it"))
 	})
 
 	It("should fail if an owned CRD does not exist", func() {
@@ -269,6 +272,73 @@ var _ = Describe("OperatorListOperands", func() {
 			types.NamespacedName{Name: "cluster3", Namespace: ""},
 		))
 	})
+
+	It("should watch operands and follow kinds added to the CSV", func() {
+		og.Status.Namespaces = []string{"ns1"}
+		Expect(cfg.Client.Update(context.TODO(), og)).To(Succeed())
+
+		ctx, cancel := context.WithCancel(context.TODO())
+		defer cancel()
+		events := make(chan action.WatchEvent, 10)
+		done := make(chan error, 1)
+		go func() {
+			done <- action.NewOperatorListOperands(&cfg).Watch(ctx, "etcd", func(e action.WatchEvent) error {
+				events <- e
+				return nil
+			})
+		}()
+
+		names := []types.NamespacedName{}
+		for i := 0; i < 2; i++ {
+			var e action.WatchEvent
+			Eventually(events).Should(Receive(&e))
+			Expect(e.Type).To(Equal(watch.Added))
+			names = append(names, client.ObjectKeyFromObject(e.Object))
+		}
+		Expect(names).To(ConsistOf(
+			types.NamespacedName{Name: "cluster1", Namespace: "ns1"},
+			types.NamespacedName{Name: "cluster3", Namespace: ""},
+		))
+
+		// An upgrade adds an owned CRD.
+		etcdbackupGVK := schema.GroupVersionKind{Group: "etcd.database.coreos.com", Version: "v1beta2", Kind: "EtcdBackup"}
+		cfg.Scheme.AddKnownTypeWithName(etcdbackupGVK, &unstructured.Unstructured{})
+		cfg.Scheme.AddKnownTypeWithName(etcdbackupGVK.GroupVersion().WithKind("EtcdBackupList"), &unstructured.UnstructuredList{})
+		backup := &unstructured.Unstructured{}
+		backup.SetGroupVersionKind(etcdbackupGVK)
+		backup.SetNamespace("ns1")
+		backup.SetName("backup1")
+		Expect(cfg.Client.Create(context.TODO(), backup)).To(Succeed())
+		Expect(cfg.Client.Create(context.TODO(), &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcdbackups.etcd.database.coreos.com"},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Group: "etcd.database.coreos.com",
+				Names: apiextensionsv1.CustomResourceDefinitionNames{ListKind: "EtcdBackupList"},
+			},
+		})).To(Succeed())
+
+		// Touch the CSV until the watch of the CSVs is open and picks up
+		// the new owned CRD.
+		Eventually(func() string {
+			Expect(cfg.Client.Get(context.TODO(), client.ObjectKeyFromObject(csv), csv)).To(Succeed())
+			csv.Spec.CustomResourceDefinitions.Owned = append(csv.Spec.CustomResourceDefinitions.Owned[:1], v1alpha1.CRDDescription{
+				Name:    "etcdbackups.etcd.database.coreos.com",
+				Version: "v1beta2",
+				Kind:    "EtcdBackup",
+			})
+			csv.Spec.Description += "."
+			Expect(cfg.Client.Update(context.TODO(), csv)).To(Succeed())
+			select {
+			case e := <-events:
+				return e.Object.GetObjectKind().GroupVersionKind().Kind + "/" + e.Object.GetName()
+			default:
+				return ""
+			}
+		}).Should(Equal("EtcdBackup/backup1"))
+
+		cancel()
+		Eventually(done).Should(Receive(MatchError(context.Canceled)))
+	})
 })
 
 func getObjectNames(objects unstructured.UnstructuredList) []types.NamespacedName {
@@ -15,39 +15,99 @@ import (
 	v1 "github.com/operator-framework/api/pkg/operators/v1"
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 
+	"github.com/operator-framework/kubectl-operator/internal/pkg/cluster"
 	"github.com/operator-framework/kubectl-operator/internal/pkg/operand"
-	"github.com/operator-framework/kubectl-operator/pkg/action"
+	"github.com/operator-framework/kubectl-operator/internal/pkg/subscription"
 )
 
-type OperatorUninstall struct {
-	config *action.Configuration
+// OperandDeletionStrategy describes how to handle operands on-cluster when
+// uninstalling the operator that owns them.
+type OperandDeletionStrategy = operand.DeletionStrategy
+
+const (
+	// OperandAbort fails the uninstall if operands exist. It is the default.
+	OperandAbort = operand.Abort
+	// OperandIgnore leaves the operands behind.
+	OperandIgnore = operand.Ignore
+	// OperandDelete deletes the operands before the operator, allowing their
+	// finalizers to run.
+	OperandDelete = operand.Delete
+)
+
+// ErrAbortStrategy is returned by OperatorUninstall when operands exist and
+// the operand strategy is OperandAbort.
+var ErrAbortStrategy = operand.ErrAbortStrategy
+
+// UninstallOptions are the settings of an OperatorUninstall.
+type UninstallOptions struct {
+	Package         string
+	OperandStrategy OperandDeletionStrategy
 
-	Package                  string
-	OperandStrategy          operand.DeletionStrategy
+	// DeleteAll implies DeleteOperator and DeleteOperatorGroups, and
+	// DeleteOperator implies the OperandDelete strategy.
 	DeleteAll                bool
 	DeleteOperator           bool
 	DeleteOperatorGroups     bool
 	DeleteOperatorGroupNames []string
-	Logf                     func(string, ...interface{})
 }
 
-func NewOperatorUninstall(cfg *action.Configuration) *OperatorUninstall {
+// OperatorUninstall deletes a package's subscription and CSV, and optionally
+// its operands, operator object and operator groups.
+type OperatorUninstall struct {
+	config *Configuration
+
+	UninstallOptions
+
+	// Events, if set, receives the progress of the uninstall. Logf is called
+	// with the message of each event that has one.
+	Events EventSink
+	Logf   func(string, ...interface{})
+}
+
+// Phases reported in the events of OperatorUninstall.
+const (
+	UninstallPhaseSubscription   = "Subscription"
+	UninstallPhaseOperands       = "Operands"
+	UninstallPhaseCSV            = "ClusterServiceVersion"
+	UninstallPhaseOperator       = "Operator"
+	UninstallPhaseOperatorGroups = "OperatorGroups"
+
+	ConditionReferencesDeleted = "ReferencesDeleted"
+)
+
+func NewOperatorUninstall(cfg *Configuration) *OperatorUninstall {
 	return &OperatorUninstall{
-		config:          cfg,
-		OperandStrategy: operand.Abort,
-		Logf:            func(string, ...interface{}) {},
+		config: cfg,
+		UninstallOptions: UninstallOptions{
+			OperandStrategy: OperandAbort,
+		},
+		Logf: func(string, ...interface{}) {},
+	}
+}
+
+func (u *OperatorUninstall) Run(ctx context.Context) error {
+	if err := u.run(ctx); err != nil {
+		u.emit(Event{Type: EventError, Err: err})
+		return err
 	}
+	return nil
 }
 
-type ErrPackageNotFound struct {
-	PackageName string
+func (u *OperatorUninstall) emit(e Event) {
+	emitEvent(u.Events, u.Logf, e)
 }
 
-func (e ErrPackageNotFound) Error() string {
-	return fmt.Sprintf("package %q not found", e.PackageName)
+// phase reports the start and completion of the named phase around fn.
+func (u *OperatorUninstall) phase(name string, fn func() error) error {
+	u.emit(Event{Type: EventPhaseStarted, Phase: name})
+	if err := fn(); err != nil {
+		return err
+	}
+	u.emit(Event{Type: EventPhaseCompleted, Phase: name})
+	return nil
 }
 
-func (u *OperatorUninstall) Run(ctx context.Context) error {
+func (u *OperatorUninstall) run(ctx context.Context) error {
 	if u.DeleteAll {
 		u.DeleteOperator = true
 		u.DeleteOperatorGroups = true
@@ -62,7 +122,7 @@ func (u *OperatorUninstall) Run(ctx context.Context) error {
 
 	subs := v1alpha1.SubscriptionList{}
 	if err := u.config.Client.List(ctx, &subs, client.InNamespace(u.config.Namespace)); err != nil {
-		return fmt.Errorf("list subscriptions: %v", err)
+		return fmt.Errorf("list subscriptions: %w", err)
 	}
 
 	var sub *v1alpha1.Subscription
@@ -74,22 +134,23 @@ func (u *OperatorUninstall) Run(ctx context.Context) error {
 		}
 	}
 	if sub == nil {
-		return &ErrPackageNotFound{u.Package}
+		return &ErrPackageNotFound{PackageName: u.Package}
 	}
+	sub.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.SubscriptionKind))
 
 	csv, csvName, err := u.getSubscriptionCSV(ctx, sub)
 	if err != nil && !apierrors.IsNotFound(err) {
 		if csvName == "" {
-			return fmt.Errorf("get subscription csv: %v", err)
+			return fmt.Errorf("get subscription csv: %w", err)
 		}
-		return fmt.Errorf("get subscription csv %q: %v", csvName, err)
+		return fmt.Errorf("get subscription csv %q: %w", csvName, err)
 	}
 
 	// find operands related to the operator on cluster
-	lister := action.NewOperatorListOperands(u.config)
+	lister := NewOperatorListOperands(u.config)
 	operands, err := lister.Run(ctx, u.Package)
 	if err != nil {
-		return fmt.Errorf("list operands for operator %q: %v", u.Package, err)
+		return fmt.Errorf("list operands for operator %q: %w", u.Package, err)
 	}
 	// validate the provided deletion strategy before proceeding to deletion
 	if err := u.validStrategy(operands); err != nil {
@@ -113,14 +174,14 @@ func (u *OperatorUninstall) Run(ctx context.Context) error {
 	*/
 
 	// Subscriptions can be deleted asynchronously.
-	if err := u.deleteObjects(ctx, sub); err != nil {
+	if err := u.phase(UninstallPhaseSubscription, func() error { return u.deleteObjects(ctx, sub) }); err != nil {
 		return err
 	}
 
 	// If we could not find a csv associated with the subscription, that likely
 	// means there is no CSV associated with it yet. Delete non-CSV related items only like the operatorgroup.
 	if csv == nil {
-		u.Logf("csv for package %q not found", u.Package)
+		u.emit(Event{Type: EventWarning, Message: fmt.Sprintf("csv for package %q not found", u.Package)})
 	} else {
 		if err := u.deleteCSVRelatedResources(ctx, csv, operands); err != nil {
 			return err
@@ -128,14 +189,14 @@ func (u *OperatorUninstall) Run(ctx context.Context) error {
 	}
 
 	if u.DeleteOperator {
-		if err := u.deleteOperator(ctx); err != nil {
-			return fmt.Errorf("delete operator: %v", err)
+		if err := u.phase(UninstallPhaseOperator, func() error { return u.deleteOperator(ctx) }); err != nil {
+			return fmt.Errorf("delete operator: %w", err)
 		}
 	}
 
 	if u.DeleteOperatorGroups {
-		if err := u.deleteOperatorGroup(ctx); err != nil {
-			return fmt.Errorf("delete operatorgroup: %v", err)
+		if err := u.phase(UninstallPhaseOperatorGroups, func() error { return u.deleteOperatorGroup(ctx) }); err != nil {
+			return fmt.Errorf("delete operatorgroup: %w", err)
 		}
 	}
 
@@ -149,19 +210,24 @@ func (u *OperatorUninstall) operatorName() string {
 func (u *OperatorUninstall) deleteObjects(ctx context.Context, objs ...client.Object) error {
 	for _, obj := range objs {
 		obj := obj
-		lowerKind := strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind)
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		lowerKind := strings.ToLower(kind)
 		if err := u.config.Client.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
-			return fmt.Errorf("delete %s %q: %v", lowerKind, obj.GetName(), err)
+			return fmt.Errorf("delete %s %q: %w", lowerKind, obj.GetName(), err)
 		} else if err == nil {
-			u.Logf("%s %q deleted", lowerKind, obj.GetName())
+			u.emit(Event{
+				Type:    EventObjectDeleted,
+				Object:  ReferenceTo(kind, obj),
+				Message: fmt.Sprintf("%s %q deleted", lowerKind, obj.GetName()),
+			})
 		}
 	}
-	return waitForDeletion(ctx, u.config.Client, objs...)
+	return cluster.WaitForDeletion(ctx, u.config.Client, objs...)
 }
 
 // getSubscriptionCSV looks up the installed CSV name from the provided subscription and fetches it.
-func (u *OperatorUninstall) getSubscriptionCSV(ctx context.Context, subscription *v1alpha1.Subscription) (*v1alpha1.ClusterServiceVersion, string, error) {
-	name := csvNameFromSubscription(subscription)
+func (u *OperatorUninstall) getSubscriptionCSV(ctx context.Context, sub *v1alpha1.Subscription) (*v1alpha1.ClusterServiceVersion, string, error) {
+	name := subscription.CSVName(sub)
 
 	// If we could not find a name in the subscription, that likely
 	// means there is no CSV associated with it yet. This should
@@ -172,7 +238,7 @@ func (u *OperatorUninstall) getSubscriptionCSV(ctx context.Context, subscription
 
 	key := types.NamespacedName{
 		Name:      name,
-		Namespace: subscription.GetNamespace(),
+		Namespace: sub.GetNamespace(),
 	}
 
 	csv := &v1alpha1.ClusterServiceVersion{}
@@ -211,11 +277,13 @@ func (u *OperatorUninstall) deleteOperator(ctx context.Context) error {
 		objs = append(objs, &obj)
 	}
 	if err := u.deleteObjects(ctx, objs...); err != nil {
-		return fmt.Errorf("delete operator references: %v", err)
+		return fmt.Errorf("delete operator references: %w", err)
 	}
 
 	// wait until all of the objects we just deleted disappear from the
 	// operator's references.
+	opRef := ReferenceTo("Operator", &op)
+	u.emit(Event{Type: EventWaiting, Phase: UninstallPhaseOperator, Object: opRef, Condition: ConditionReferencesDeleted})
 	if err := wait.PollUntilContextCancel(ctx, time.Millisecond*100, true, func(conditionCtx context.Context) (bool, error) {
 		var check v1.Operator
 		if err := u.config.Client.Get(conditionCtx, key, &check); err != nil {
@@ -231,11 +299,12 @@ func (u *OperatorUninstall) deleteOperator(ctx context.Context) error {
 	}); err != nil {
 		return err
 	}
+	u.emit(Event{Type: EventConditionMet, Phase: UninstallPhaseOperator, Object: opRef, Condition: ConditionReferencesDeleted})
 
 	// delete the operator
 	op.SetGroupVersionKind(v1.GroupVersion.WithKind("Operator"))
 	if err := u.deleteObjects(ctx, &op); err != nil {
-		return fmt.Errorf("delete operator: %v", err)
+		return fmt.Errorf("delete operator: %w", err)
 	}
 
 	return nil
@@ -244,14 +313,14 @@ func (u *OperatorUninstall) deleteOperator(ctx context.Context) error {
 func (u *OperatorUninstall) deleteOperatorGroup(ctx context.Context) error {
 	subs := v1alpha1.SubscriptionList{}
 	if err := u.config.Client.List(ctx, &subs, client.InNamespace(u.config.Namespace)); err != nil {
-		return fmt.Errorf("list subscriptions: %v", err)
+		return fmt.Errorf("list subscriptions: %w", err)
 	}
 
 	// If there are no subscriptions left, delete the operator group(s).
 	if len(subs.Items) == 0 {
 		ogs := v1.OperatorGroupList{}
 		if err := u.config.Client.List(ctx, &ogs, client.InNamespace(u.config.Namespace)); err != nil {
-			return fmt.Errorf("list operatorgroups: %v", err)
+			return fmt.Errorf("list operatorgroups: %w", err)
 		}
 		for _, og := range ogs.Items {
 			og := og
@@ -274,37 +343,41 @@ func (u *OperatorUninstall) validStrategy(operands *unstructured.UnstructuredLis
 }
 
 func (u *OperatorUninstall) deleteCSVRelatedResources(ctx context.Context, csv *v1alpha1.ClusterServiceVersion, operands *unstructured.UnstructuredList) error {
-	switch u.OperandStrategy {
-	case operand.Ignore:
-		for _, op := range operands.Items {
-			u.Logf("%s %q orphaned", strings.ToLower(op.GetKind()), prettyPrint(op))
-		}
-	case operand.Delete:
-		for _, op := range operands.Items {
-			op := op
-			if err := u.deleteObjects(ctx, &op); err != nil {
-				return fmt.Errorf("delete operand: %v", err)
+	if err := u.phase(UninstallPhaseOperands, func() error {
+		switch u.OperandStrategy {
+		case operand.Ignore:
+			for _, op := range operands.Items {
+				op := op
+				u.emit(Event{
+					Type:    EventWarning,
+					Phase:   UninstallPhaseOperands,
+					Object:  ReferenceTo(op.GetKind(), &op),
+					Message: fmt.Sprintf("%s %q orphaned", strings.ToLower(op.GetKind()), prettyPrint(op)),
+				})
+			}
+		case operand.Delete:
+			for _, op := range operands.Items {
+				op := op
+				if err := u.deleteObjects(ctx, &op); err != nil {
+					return fmt.Errorf("delete operand: %w", err)
+				}
 			}
 		}
+		return nil
+	}); err != nil {
+		return err
 	}
 
 	// OLM puts an ownerref on every namespaced resource to the CSV,
 	// and an owner label on every cluster scoped resource. When CSV is deleted
 	// kube and olm gc will remove all the referenced resources.
-	if err := u.deleteObjects(ctx, csv); err != nil {
-		return fmt.Errorf("delete csv: %v", err)
+	if err := u.phase(UninstallPhaseCSV, func() error { return u.deleteObjects(ctx, csv) }); err != nil {
+		return fmt.Errorf("delete csv: %w", err)
 	}
 
 	return nil
 }
 
-func csvNameFromSubscription(subscription *v1alpha1.Subscription) string {
-	if subscription.Status.InstalledCSV != "" {
-		return subscription.Status.InstalledCSV
-	}
-	return subscription.Status.CurrentCSV
-}
-
 func contains(haystack []string, needle string) bool {
 	for _, n := range haystack {
 		if n == needle {
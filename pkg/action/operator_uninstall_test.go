@@ -18,8 +18,6 @@ import (
 	v1 "github.com/operator-framework/api/pkg/operators/v1"
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 
-	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
-	"github.com/operator-framework/kubectl-operator/internal/pkg/operand"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
@@ -149,11 +147,11 @@ var _ = Describe("OperatorUninstall", func() {
 	})
 
 	It("should fail due to missing subscription", func() {
-		uninstaller := internalaction.NewOperatorUninstall(&cfg)
+		uninstaller := action.NewOperatorUninstall(&cfg)
 		// switch to package without a subscription for it
 		uninstaller.Package = "redis"
 		err := uninstaller.Run(context.TODO())
-		Expect(err).To(MatchError(&internalaction.ErrPackageNotFound{PackageName: "redis"}))
+		Expect(err).To(MatchError(&action.ErrPackageNotFound{PackageName: "redis"}))
 	})
 
 	It("should not fail due to missing csv", func() {
@@ -162,9 +160,9 @@ var _ = Describe("OperatorUninstall", func() {
 		sub.Status.InstalledCSV = ""
 		Expect(cfg.Client.Update(context.TODO(), sub)).To(Succeed())
 
-		uninstaller := internalaction.NewOperatorUninstall(&cfg)
+		uninstaller := action.NewOperatorUninstall(&cfg)
 		uninstaller.Package = etcd
-		uninstaller.OperandStrategy = operand.Ignore
+		uninstaller.OperandStrategy = action.OperandIgnore
 		err := uninstaller.Run(context.TODO())
 		Expect(err).To(BeNil())
 
@@ -174,7 +172,7 @@ var _ = Describe("OperatorUninstall", func() {
 	})
 
 	It("should fail due to invalid operand deletion strategy", func() {
-		uninstaller := internalaction.NewOperatorUninstall(&cfg)
+		uninstaller := action.NewOperatorUninstall(&cfg)
 		uninstaller.Package = etcd
 		uninstaller.OperandStrategy = "foo"
 		err := uninstaller.Run(context.TODO())
@@ -182,17 +180,17 @@ var _ = Describe("OperatorUninstall", func() {
 	})
 
 	It("should error with operands on cluster when default abort strategy is set", func() {
-		uninstaller := internalaction.NewOperatorUninstall(&cfg)
+		uninstaller := action.NewOperatorUninstall(&cfg)
 		uninstaller.Package = etcd
-		uninstaller.OperandStrategy = operand.Abort
+		uninstaller.OperandStrategy = action.OperandAbort
 		err := uninstaller.Run(context.TODO())
-		Expect(err).To(MatchError(operand.ErrAbortStrategy))
+		Expect(err).To(MatchError(action.ErrAbortStrategy))
 	})
 
 	It("should ignore operands and delete sub and csv when ignore strategy is set", func() {
-		uninstaller := internalaction.NewOperatorUninstall(&cfg)
+		uninstaller := action.NewOperatorUninstall(&cfg)
 		uninstaller.Package = etcd
-		uninstaller.OperandStrategy = operand.Ignore
+		uninstaller.OperandStrategy = action.OperandIgnore
 		err := uninstaller.Run(context.TODO())
 		Expect(err).To(BeNil())
 
@@ -216,11 +214,24 @@ var _ = Describe("OperatorUninstall", func() {
 	})
 
 	It("should delete sub, csv, and operands when delete strategy is set", func() {
-		uninstaller := internalaction.NewOperatorUninstall(&cfg)
+		deleted := []string{}
+		uninstaller := action.NewOperatorUninstall(&cfg)
 		uninstaller.Package = etcd
-		uninstaller.OperandStrategy = operand.Delete
+		uninstaller.OperandStrategy = action.OperandDelete
+		uninstaller.Events = action.EventSinkFunc(func(e action.Event) {
+			if e.Type == action.EventObjectDeleted {
+				deleted = append(deleted, e.Object.Kind+"/"+e.Object.Name)
+			}
+		})
 		err := uninstaller.Run(context.TODO())
 		Expect(err).To(BeNil())
+		Expect(deleted).To(Equal([]string{
+			"Subscription/etcd-sub",
+			"EtcdCluster/cluster3",
+			"EtcdCluster/cluster1",
+			"EtcdCluster/cluster2",
+			"ClusterServiceVersion/etcdoperator.v0.9.4-clusterwide",
+		}))
 
 		subKey := types.NamespacedName{Name: "etcd-sub", Namespace: "etcd-namespace"}
 		s := &v1alpha1.Subscription{}
@@ -240,9 +251,9 @@ var _ = Describe("OperatorUninstall", func() {
 		Expect(cfg.Client.Get(context.TODO(), etcd3Key, etcdcluster3)).To(WithTransform(apierrors.IsNotFound, BeTrue()))
 	})
 	It("should delete sub and operatorgroup when no CSV is found", func() {
-		uninstaller := internalaction.NewOperatorUninstall(&cfg)
+		uninstaller := action.NewOperatorUninstall(&cfg)
 		uninstaller.Package = etcd
-		uninstaller.OperandStrategy = operand.Ignore
+		uninstaller.OperandStrategy = action.OperandIgnore
 		uninstaller.DeleteOperatorGroups = true
 
 		sub.Status.InstalledCSV = "foo" // returns nil CSV
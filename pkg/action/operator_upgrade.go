@@ -9,17 +9,23 @@ import (
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 
-	"github.com/operator-framework/kubectl-operator/pkg/action"
+	"github.com/operator-framework/kubectl-operator/internal/pkg/cluster"
 )
 
+// UpgradeOptions are the settings of an OperatorUpgrade.
+type UpgradeOptions struct {
+	Package string
+}
+
+// OperatorUpgrade approves the pending install plan of a package's
+// subscription and waits for it to complete.
 type OperatorUpgrade struct {
-	config *action.Configuration
+	config *Configuration
 
-	Package string
-	Channel string
+	UpgradeOptions
 }
 
-func NewOperatorUpgrade(cfg *action.Configuration) *OperatorUpgrade {
+func NewOperatorUpgrade(cfg *Configuration) *OperatorUpgrade {
 	return &OperatorUpgrade{
 		config: cfg,
 	}
@@ -36,13 +42,17 @@ func (u *OperatorUpgrade) Run(ctx context.Context) (*v1alpha1.ClusterServiceVers
 		return nil, err
 	}
 
-	if err := approveInstallPlan(ctx, u.config.Client, ip); err != nil {
-		return nil, fmt.Errorf("approve install plan: %v", err)
+	if err := cluster.CheckInstallPlanVersionConstraint(ctx, u.config.Client, sub, ip); err != nil {
+		return nil, err
+	}
+
+	if err := cluster.ApproveInstallPlan(ctx, u.config.Client, ip); err != nil {
+		return nil, fmt.Errorf("approve install plan: %w", err)
 	}
 
 	csv, err := getCSV(ctx, u.config.Client, ip)
 	if err != nil {
-		return nil, fmt.Errorf("get clusterserviceversion: %v", err)
+		return nil, fmt.Errorf("get clusterserviceversion: %w", err)
 	}
 	return csv, nil
 }
@@ -50,7 +60,7 @@ func (u *OperatorUpgrade) Run(ctx context.Context) (*v1alpha1.ClusterServiceVers
 func (u *OperatorUpgrade) findSubscriptionForPackage(ctx context.Context) (*v1alpha1.Subscription, error) {
 	subs := v1alpha1.SubscriptionList{}
 	if err := u.config.Client.List(ctx, &subs, client.InNamespace(u.config.Namespace)); err != nil {
-		return nil, fmt.Errorf("list subscriptions: %v", err)
+		return nil, fmt.Errorf("list subscriptions: %w", err)
 	}
 
 	for _, s := range subs.Items {
@@ -59,7 +69,7 @@ func (u *OperatorUpgrade) findSubscriptionForPackage(ctx context.Context) (*v1al
 			return &s, nil
 		}
 	}
-	return nil, fmt.Errorf("subscription for package %q not found", u.Package)
+	return nil, &ErrPackageNotFound{PackageName: u.Package}
 }
 
 func (u *OperatorUpgrade) getInstallPlan(ctx context.Context, sub *v1alpha1.Subscription) (*v1alpha1.InstallPlan, error) {
@@ -76,7 +86,7 @@ func (u *OperatorUpgrade) getInstallPlan(ctx context.Context, sub *v1alpha1.Subs
 		Name:      sub.Status.InstallPlanRef.Name,
 	}
 	if err := u.config.Client.Get(ctx, ipKey, &ip); err != nil {
-		return nil, fmt.Errorf("get install plan: %v", err)
+		return nil, fmt.Errorf("get install plan: %w", err)
 	}
 	return &ip, nil
 }
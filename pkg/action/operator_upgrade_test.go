@@ -0,0 +1,200 @@
+package action_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/subscription"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("OperatorUpgrade", func() {
+	var (
+		cfg action.Configuration
+		sub *v1alpha1.Subscription
+		ip  *v1alpha1.InstallPlan
+
+		// otherPMs are served alongside the package manifest of the
+		// subscription's catalog.
+		otherPMs []operatorsv1.PackageManifest
+	)
+
+	BeforeEach(func() {
+		otherPMs = nil
+	})
+
+	build := func(constraint, nextCSV string) {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+
+		pm := operatorsv1.PackageManifest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "etcd",
+				Namespace: "etcd-namespace",
+				Labels:    map[string]string{"catalog": "operatorhubio", "catalog-namespace": "olm"},
+			},
+			Status: operatorsv1.PackageManifestStatus{
+				DefaultChannel: "stable",
+				Channels: []operatorsv1.PackageChannel{{
+					Name:       "stable",
+					CurrentCSV: "etcdoperator.v2.0.0",
+					Entries: []operatorsv1.ChannelEntry{
+						{Name: "etcdoperator.v2.0.0", Version: "2.0.0"},
+						{Name: "etcdoperator.v1.5.0", Version: "1.5.0"},
+						{Name: "etcdoperator.v1.4.0", Version: "1.4.0"},
+					},
+				}},
+			},
+		}
+
+		sub = &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "etcd",
+				Namespace: "etcd-namespace",
+			},
+			Spec: &v1alpha1.SubscriptionSpec{
+				Package:                "etcd",
+				Channel:                "stable",
+				CatalogSource:          "operatorhubio",
+				CatalogSourceNamespace: "olm",
+			},
+			Status: v1alpha1.SubscriptionStatus{
+				InstalledCSV: "etcdoperator.v1.4.0",
+				CurrentCSV:   nextCSV,
+				InstallPlanRef: &corev1.ObjectReference{
+					Name:      "install-next",
+					Namespace: "etcd-namespace",
+				},
+			},
+		}
+		if constraint != "" {
+			subscription.VersionConstraint(constraint)(sub)
+		}
+
+		ip = &v1alpha1.InstallPlan{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "install-next",
+				Namespace: "etcd-namespace",
+			},
+			Spec: v1alpha1.InstallPlanSpec{
+				ClusterServiceVersionNames: []string{nextCSV},
+				Approval:                   v1alpha1.ApprovalManual,
+			},
+			Status: v1alpha1.InstallPlanStatus{
+				Phase: v1alpha1.InstallPlanPhaseComplete,
+				Plan: []*v1alpha1.Step{{
+					Resource: v1alpha1.StepResource{
+						Kind: "ClusterServiceVersion",
+						Name: nextCSV,
+					},
+				}},
+			},
+		}
+
+		csv := &v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      nextCSV,
+				Namespace: "etcd-namespace",
+			},
+		}
+
+		cfg.Scheme = sch
+		// packageserver serves a package manifest per catalog under the
+		// package's name, which the fake client's tracker cannot store.
+		pms := append([]operatorsv1.PackageManifest{pm}, otherPMs...)
+		funcs := interceptor.Funcs{List: func(ctx context.Context, cl client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			pmList, ok := list.(*operatorsv1.PackageManifestList)
+			if !ok {
+				return cl.List(ctx, list, opts...)
+			}
+			lo := &client.ListOptions{}
+			lo.ApplyOptions(opts)
+			pmList.Items = nil
+			for _, pm := range pms {
+				if lo.LabelSelector == nil || lo.LabelSelector.Matches(labels.Set(pm.Labels)) {
+					pmList.Items = append(pmList.Items, pm)
+				}
+			}
+			return nil
+		}}
+		cfg.Client = fake.NewClientBuilder().WithObjects(sub, ip, csv).WithScheme(sch).WithInterceptorFuncs(funcs).Build()
+		cfg.Namespace = "etcd-namespace"
+	}
+
+	It("should approve install plans without a version constraint", func() {
+		build("", "etcdoperator.v2.0.0")
+		u := action.NewOperatorUpgrade(&cfg)
+		u.Package = "etcd"
+		csv, err := u.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(csv.Name).To(Equal("etcdoperator.v2.0.0"))
+	})
+
+	It("should approve install plans inside the version constraint", func() {
+		build(">=1.4 <2.0", "etcdoperator.v1.5.0")
+		u := action.NewOperatorUpgrade(&cfg)
+		u.Package = "etcd"
+		csv, err := u.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(csv.Name).To(Equal("etcdoperator.v1.5.0"))
+	})
+
+	It("should return a typed error for a package without a subscription", func() {
+		build("", "etcdoperator.v2.0.0")
+		u := action.NewOperatorUpgrade(&cfg)
+		u.Package = "redis"
+		_, err := u.Run(context.TODO())
+		var notFound *action.ErrPackageNotFound
+		Expect(errors.As(err, &notFound)).To(BeTrue())
+		Expect(notFound.PackageName).To(Equal("redis"))
+	})
+
+	It("should check the version constraint against the subscription's catalog", func() {
+		// A mirror lists the next csv under a version inside the constraint.
+		otherPMs = []operatorsv1.PackageManifest{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "etcd",
+				Namespace: "etcd-namespace",
+				Labels:    map[string]string{"catalog": "internal", "catalog-namespace": "mirrors"},
+			},
+			Status: operatorsv1.PackageManifestStatus{
+				Channels: []operatorsv1.PackageChannel{{
+					Name:    "stable",
+					Entries: []operatorsv1.ChannelEntry{{Name: "etcdoperator.v2.0.0", Version: "1.9.0"}},
+				}},
+			},
+		}}
+		build(">=1.4 <2.0", "etcdoperator.v2.0.0")
+		u := action.NewOperatorUpgrade(&cfg)
+		u.Package = "etcd"
+		_, err := u.Run(context.TODO())
+		Expect(err).To(MatchError(ContainSubstring(`version "2.0.0" does not satisfy constraint ">=1.4 <2.0"`)))
+	})
+
+	It("should refuse install plans outside the version constraint", func() {
+		build(">=1.4 <2.0", "etcdoperator.v2.0.0")
+		u := action.NewOperatorUpgrade(&cfg)
+		u.Package = "etcd"
+		_, err := u.Run(context.TODO())
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`does not satisfy constraint ">=1.4 <2.0"`))
+
+		check := &v1alpha1.InstallPlan{}
+		Expect(cfg.Client.Get(context.TODO(), types.NamespacedName{Name: "install-next", Namespace: "etcd-namespace"}, check)).To(Succeed())
+		Expect(check.Spec.Approved).To(BeFalse())
+	})
+})
@@ -0,0 +1,161 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// WatchEvent is a change to a watched object. Type is one of watch.Added,
+// watch.Modified or watch.Deleted.
+type WatchEvent struct {
+	Type   watch.EventType `json:"type"`
+	Object client.Object   `json:"object"`
+}
+
+// Watch lists the objects of list's type and calls handler with an Added
+// event for each of them, then with an event for every change until ctx is
+// done or handler returns an error. When the server closes the watch, the
+// objects are listed again and only the differences are reported, so handler
+// sees each change once.
+func (c *Configuration) Watch(ctx context.Context, list client.ObjectList, handler func(WatchEvent) error, opts ...client.ListOption) error {
+	wc, ok := c.Client.(client.WithWatch)
+	if !ok {
+		return fmt.Errorf("client does not support watches")
+	}
+
+	w := watcher{config: c, client: wc, list: list, opts: opts, handler: handler, seen: map[types.NamespacedName]client.Object{}}
+	for {
+		rv, err := w.relist(ctx)
+		if err != nil {
+			return err
+		}
+		if err := w.watch(ctx, rv); err != nil {
+			return err
+		}
+	}
+}
+
+type watcher struct {
+	config  *Configuration
+	client  client.WithWatch
+	list    client.ObjectList
+	opts    []client.ListOption
+	handler func(WatchEvent) error
+
+	// seen holds the last reported state of each object, to tell which
+	// objects changed while no watch was open.
+	seen map[types.NamespacedName]client.Object
+}
+
+// relist reports the objects that were added, modified or deleted since they
+// were last seen, and returns the resource version to watch from.
+func (w *watcher) relist(ctx context.Context) (string, error) {
+	list := w.list.DeepCopyObject().(client.ObjectList)
+	if err := w.client.List(ctx, list, w.opts...); err != nil {
+		return "", err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return "", err
+	}
+
+	current := map[types.NamespacedName]bool{}
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok {
+			return "", fmt.Errorf("unexpected list item type %T", item)
+		}
+		key := client.ObjectKeyFromObject(obj)
+		current[key] = true
+		eventType := watch.Added
+		if last, ok := w.seen[key]; ok {
+			if last.GetResourceVersion() == obj.GetResourceVersion() {
+				continue
+			}
+			eventType = watch.Modified
+		}
+		if err := w.report(eventType, obj); err != nil {
+			return "", err
+		}
+	}
+
+	deleted := []types.NamespacedName{}
+	for key := range w.seen {
+		if !current[key] {
+			deleted = append(deleted, key)
+		}
+	}
+	sort.Slice(deleted, func(i, j int) bool { return deleted[i].String() < deleted[j].String() })
+	for _, key := range deleted {
+		if err := w.report(watch.Deleted, w.seen[key]); err != nil {
+			return "", err
+		}
+	}
+	return list.GetResourceVersion(), nil
+}
+
+// watch reports changes from resourceVersion on until the server closes the
+// watch or the resource version expires, in which case the caller lists again.
+func (w *watcher) watch(ctx context.Context, resourceVersion string) error {
+	opts := append(append([]client.ListOption{}, w.opts...), &client.ListOptions{Raw: &metav1.ListOptions{ResourceVersion: resourceVersion}})
+	wi, err := w.client.Watch(ctx, w.list.DeepCopyObject().(client.ObjectList), opts...)
+	if err != nil {
+		return err
+	}
+	defer wi.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e, ok := <-wi.ResultChan():
+			if !ok {
+				return nil
+			}
+			switch e.Type {
+			case watch.Bookmark:
+				continue
+			case watch.Error:
+				err := apierrors.FromObject(e.Object)
+				if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+					return nil
+				}
+				return err
+			}
+			obj, ok := e.Object.(client.Object)
+			if !ok {
+				return fmt.Errorf("unexpected watch object type %T", e.Object)
+			}
+			if err := w.report(e.Type, obj); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (w *watcher) report(eventType watch.EventType, obj client.Object) error {
+	key := client.ObjectKeyFromObject(obj)
+	if eventType == watch.Deleted {
+		delete(w.seen, key)
+	} else {
+		w.seen[key] = obj
+	}
+
+	// Typed list items and watch objects usually come without their kind,
+	// which consumers of the events need to tell them apart.
+	if obj.GetObjectKind().GroupVersionKind().Empty() && w.config.Scheme != nil {
+		if gvk, err := apiutil.GVKForObject(obj, w.config.Scheme); err == nil {
+			obj.GetObjectKind().SetGroupVersionKind(gvk)
+		}
+	}
+	return w.handler(WatchEvent{Type: eventType, Object: obj})
+}
@@ -0,0 +1,125 @@
+package action_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("Watch", func() {
+	var (
+		cfg     action.Configuration
+		fw      *watch.FakeWatcher
+		watches chan struct{}
+		events  chan action.WatchEvent
+		done    chan error
+		cancel  context.CancelFunc
+	)
+
+	catalogSource := func(name string) *v1alpha1.CatalogSource {
+		return &v1alpha1.CatalogSource{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "olm"}}
+	}
+
+	receive := func() (watch.EventType, string) {
+		var e action.WatchEvent
+		EventuallyWithOffset(1, events).Should(Receive(&e))
+		return e.Type, e.Object.GetName()
+	}
+
+	BeforeEach(func() {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+
+		// The first watch is a fake watcher the tests control; later ones,
+		// opened after the first is closed, are served by the fake client.
+		fw = watch.NewFake()
+		watches = make(chan struct{}, 10)
+		calls := 0
+		cfg.Scheme = sch
+		cfg.Client = fake.NewClientBuilder().
+			WithScheme(sch).
+			WithObjects(catalogSource("a"), catalogSource("b")).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Watch: func(ctx context.Context, cl client.WithWatch, list client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+					defer func() { watches <- struct{}{} }()
+					calls++
+					if calls == 1 {
+						return fw, nil
+					}
+					return cl.Watch(ctx, list, opts...)
+				},
+			}).
+			Build()
+
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.TODO())
+		events = make(chan action.WatchEvent, 10)
+		done = make(chan error, 1)
+		go func() {
+			done <- cfg.Watch(ctx, &v1alpha1.CatalogSourceList{}, func(e action.WatchEvent) error {
+				events <- e
+				return nil
+			}, client.InNamespace("olm"))
+		}()
+	})
+
+	AfterEach(func() {
+		cancel()
+		Eventually(done).Should(Receive(MatchError(context.Canceled)))
+	})
+
+	It("should report listed objects and then watch events", func() {
+		t, name := receive()
+		Expect(t).To(Equal(watch.Added))
+		Expect(name).To(Equal("a"))
+		t, name = receive()
+		Expect(t).To(Equal(watch.Added))
+		Expect(name).To(Equal("b"))
+
+		Eventually(watches).Should(Receive())
+		fw.Add(catalogSource("c"))
+		var e action.WatchEvent
+		Eventually(events).Should(Receive(&e))
+		Expect(e.Type).To(Equal(watch.Added))
+		Expect(e.Object.GetName()).To(Equal("c"))
+		Expect(e.Object.GetObjectKind().GroupVersionKind().Kind).To(Equal("CatalogSource"))
+	})
+
+	It("should only report differences when it lists again", func() {
+		receive()
+		receive()
+		Eventually(watches).Should(Receive())
+
+		a := catalogSource("a")
+		Expect(cfg.Client.Get(context.TODO(), client.ObjectKeyFromObject(a), a)).To(Succeed())
+		a.Spec.DisplayName = "A"
+		Expect(cfg.Client.Update(context.TODO(), a)).To(Succeed())
+		Expect(cfg.Client.Delete(context.TODO(), catalogSource("b"))).To(Succeed())
+		fw.Stop()
+
+		t, name := receive()
+		Expect(t).To(Equal(watch.Modified))
+		Expect(name).To(Equal("a"))
+		t, name = receive()
+		Expect(t).To(Equal(watch.Deleted))
+		Expect(name).To(Equal("b"))
+
+		Eventually(watches).Should(Receive())
+		Expect(cfg.Client.Create(context.TODO(), catalogSource("d"))).To(Succeed())
+		t, name = receive()
+		Expect(t).To(Equal(watch.Added))
+		Expect(name).To(Equal("d"))
+		Consistently(events).ShouldNot(Receive())
+	})
+})